@@ -0,0 +1,67 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// LoadConfigAuto loads a configuration file whose format is detected from its
+// content rather than its extension. Content starting with "{" or "[" is
+// treated as JSON and checked for JSON syntax errors, anything else is treated
+// as YAML. Both formats are decoded using the struct's yaml tags and validated
+// in the same way as LoadConfig.
+//
+// Parameters:
+//
+// path: The path to the configuration file, or "-" for standard input.
+// config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
+//
+// Returns:
+// error: An error if the configuration file could not be loaded, decoded or
+// validated.
+//
+// Example:
+//
+// err := yamlconfig.LoadConfigAuto("/etc/app/config", &cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func LoadConfigAuto(path string, config interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	return o.logResult(path, loadConfigAuto(path, config, o))
+}
+
+// loadConfigAuto performs the work of LoadConfigAuto using already resolved
+// options.
+func loadConfigAuto(path string, config interface{}, o *options) error {
+	// Read the configuration file
+	data, fileErr := readConfigFile(path)
+	if fileErr != nil {
+		return fmt.Errorf("failed to load config file: %w", fileErr)
+	}
+
+	o.logger(LoadEvent{Phase: PhaseOpened, Path: path, Message: "opened config file"})
+
+	// JSON is a subset of YAML, so once the syntax has been checked the
+	// content goes through the regular YAML pipeline
+	if isJSON(data) {
+		var js interface{}
+		if jsonErr := json.Unmarshal(data, &js); jsonErr != nil {
+			return fmt.Errorf("failed to decode JSON config file: %w", jsonErr)
+		}
+	}
+
+	return decodeConfig(data, path, config, o)
+}
+
+// isJSON reports whether the content looks like a JSON document, based on its
+// first non-whitespace byte.
+func isJSON(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
@@ -0,0 +1,41 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigAuto(t *testing.T) {
+	t.Run("Load Config Auto JSON", func(t *testing.T) {
+		cfg := TestConfigOmitEmpty{}
+		path := writeTempConfig(t, "\n  {\"string\": \"test\", \"slice\": [\"a\", \"b\"]}")
+
+		require.NoError(t, yamlconfig.LoadConfigAuto(path, &cfg))
+		require.Equal(t, "test", cfg.String)
+		require.Equal(t, []string{"a", "b"}, cfg.Slice)
+	})
+
+	t.Run("Load Config Auto YAML", func(t *testing.T) {
+		cfg := TestConfigOmitEmpty{}
+		path := writeTempConfig(t, "string: test\n")
+
+		require.NoError(t, yamlconfig.LoadConfigAuto(path, &cfg))
+		require.Equal(t, "test", cfg.String)
+	})
+
+	t.Run("Load Config Auto Invalid JSON", func(t *testing.T) {
+		cfg := TestConfigOmitEmpty{}
+		path := writeTempConfig(t, "{\"string\": \"test\",}")
+
+		require.ErrorContains(t, yamlconfig.LoadConfigAuto(path, &cfg), "failed to decode JSON config file")
+	})
+
+	t.Run("Load Config Auto Validates", func(t *testing.T) {
+		cfg := TestConfigOmitEmpty{}
+		path := writeTempConfig(t, "{\"slice\": [\"a\"]}")
+
+		require.Error(t, yamlconfig.LoadConfigAuto(path, &cfg))
+	})
+}
@@ -0,0 +1,41 @@
+package yamlconfig
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeBase64 decodes a base64 string destined for a field of type typ tagged
+// yamlconfig:"base64" and replaces it with a sequence of the bytes it encodes,
+// which the decoder stores in the []byte field. Whitespace is ignored so long
+// values can be written as literal or folded blocks. Nulls are left for the
+// decoder.
+func decodeBase64(node *yaml.Node, typ reflect.Type, path string) error {
+	typ = derefType(typ)
+	if typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("base64 is only supported on []byte fields: %s", path)
+	}
+
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(node.Value), ""))
+	if err != nil {
+		return fmt.Errorf("line %d: invalid base64 for %s: %w", node.Line, path, err)
+	}
+
+	content := make([]*yaml.Node, len(decoded))
+	for i, b := range decoded {
+		content[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(int(b))}
+	}
+
+	*node = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle, Content: content, Line: node.Line, Column: node.Column}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigBase64 struct {
+	Key  []byte `yaml:"key" yamlconfig:"base64"`
+	Cert []byte `yaml:"cert" yamlconfig:"omitempty,base64"`
+}
+
+func TestBase64(t *testing.T) {
+	t.Run("Base64 Decoded", func(t *testing.T) {
+		cfg := TestConfigBase64{}
+		path := writeTempConfig(t, "key: c2VjcmV0\ncert: |\n  aGVsbG8g\n  d29ybGQ=\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, []byte("secret"), cfg.Key)
+		require.Equal(t, []byte("hello world"), cfg.Cert)
+	})
+
+	t.Run("Invalid Base64", func(t *testing.T) {
+		cfg := TestConfigBase64{}
+		path := writeTempConfig(t, "key: not-base64!\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to decode config file: line 1: invalid base64 for key: illegal base64 data at input byte 3")
+	})
+
+	t.Run("Empty Required Base64", func(t *testing.T) {
+		cfg := TestConfigBase64{}
+		path := writeTempConfig(t, "key: ''\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: key: missing required config item")
+	})
+
+	t.Run("Unsupported Field Type", func(t *testing.T) {
+		cfg := struct {
+			Key string `yaml:"key" yamlconfig:"base64"`
+		}{}
+		path := writeTempConfig(t, "key: c2VjcmV0\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to decode config file: base64 is only supported on []byte fields: key")
+	})
+}
@@ -0,0 +1,41 @@
+package yamlconfig
+
+// ValidateBytes decodes raw YAML content into the provided struct pointer and
+// validates it, returning only whether the content is a valid configuration.
+// It is intended for request handlers that receive configuration from an API
+// and only need a yes or no answer.
+//
+// Parameters:
+//
+// data: The raw YAML content.
+// config: A pointer to the struct describing the configuration schema.
+// opts: Optional settings that change how the configuration is validated.
+//
+// Returns:
+// error: An error if the content could not be decoded or is not valid.
+//
+// Example:
+//
+//	if err := yamlconfig.ValidateBytes(body, &Config{}); err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	}
+func ValidateBytes(data []byte, config interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	return o.logResult("", decodeConfig(data, "", config, o))
+}
+
+// Unmarshal decodes the YAML content in into the struct pointed to by out and
+// validates it. It has the same signature as yaml.Unmarshal from
+// gopkg.in/yaml.v3, so code decoding configuration with yaml.v3 can switch to
+// it to gain defaults and validation without other changes.
+//
+// Example:
+//
+//	var cfg Config
+//	if err := yamlconfig.Unmarshal(data, &cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+func Unmarshal(in []byte, out interface{}) error {
+	return decodeConfig(in, "", out, newOptions(nil))
+}
@@ -0,0 +1,44 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBytes(t *testing.T) {
+	t.Run("Validate Bytes Valid", func(t *testing.T) {
+		require.NoError(t, yamlconfig.ValidateBytes([]byte("string: test\n"), &TestConfigOmitEmpty{}))
+	})
+
+	t.Run("Validate Bytes Missing Required", func(t *testing.T) {
+		require.Error(t, yamlconfig.ValidateBytes([]byte("slice: [a]\n"), &TestConfigOmitEmpty{}))
+	})
+
+	t.Run("Validate Bytes Decode Error", func(t *testing.T) {
+		require.Error(t, yamlconfig.ValidateBytes([]byte("string: [\n"), &TestConfigOmitEmpty{}))
+		require.Error(t, yamlconfig.ValidateBytes(nil, &TestConfigOmitEmpty{}))
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("Unmarshal Valid", func(t *testing.T) {
+		cfg := TestConfigDefaults{}
+
+		require.NoError(t, yamlconfig.Unmarshal([]byte("name: app\n"), &cfg))
+		require.Equal(t, "app", cfg.Name)
+		require.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("Unmarshal Invalid", func(t *testing.T) {
+		require.EqualError(t, yamlconfig.Unmarshal([]byte("slice: [a]\n"), &TestConfigOmitEmpty{}),
+			"failed to load the config: string: missing required config item")
+	})
+
+	t.Run("Unmarshal Matches yaml.Unmarshal Signature", func(t *testing.T) {
+		var unmarshal func([]byte, interface{}) error = yamlconfig.Unmarshal
+
+		require.NotNil(t, unmarshal)
+	})
+}
@@ -0,0 +1,92 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ByteSize is a number of bytes that can be written in a configuration file
+// with a unit suffix, such as "512KiB" or "10MB". Decimal units (KB, MB, GB,
+// TB) are powers of 1000 and binary units (KiB, MiB, GiB, TiB) are powers of
+// 1024. A plain number is a count of bytes.
+type ByteSize uint64
+
+// byteUnits lists the accepted unit suffixes, longest first so that "MiB" is
+// matched before "B".
+var byteUnits = []struct {
+	suffix string
+	size   uint64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"TB", 1e12},
+	{"GB", 1e9},
+	{"MB", 1e6},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// ParseByteSize parses a size such as "1.5GB", "512KiB" or "1024".
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	number, multiplier := s, uint64(1)
+
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			number, multiplier = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix)), unit.size
+
+			break
+		}
+	}
+
+	if n, err := strconv.ParseUint(number, 10, 64); err == nil {
+		if n > math.MaxUint64/multiplier {
+			return 0, fmt.Errorf("byte size %q is too large", s)
+		}
+
+		return ByteSize(n * multiplier), nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil || f < 0 || f*float64(multiplier) >= math.MaxUint64 {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+
+	return ByteSize(f * float64(multiplier)), nil
+}
+
+// String formats the size using the largest unit that divides it exactly.
+func (b ByteSize) String() string {
+	best := byteUnits[len(byteUnits)-1]
+
+	for _, unit := range byteUnits {
+		if uint64(b) >= unit.size && uint64(b)%unit.size == 0 && unit.size > best.size {
+			best = unit
+		}
+	}
+
+	return strconv.FormatUint(uint64(b)/best.size, 10) + best.suffix
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	size, err := ParseByteSize(node.Value)
+	if err != nil {
+		return err
+	}
+
+	*b = size
+
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (b ByteSize) MarshalYAML() (interface{}, error) {
+	return b.String(), nil
+}
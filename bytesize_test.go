@@ -0,0 +1,50 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteSize(t *testing.T) {
+	t.Run("Parse Byte Size", func(t *testing.T) {
+		tests := map[string]yamlconfig.ByteSize{
+			"1024":   1024,
+			"10B":    10,
+			"1KB":    1000,
+			"512KiB": 512 * 1024,
+			"1.5GB":  1500000000,
+			"2 MiB":  2 * 1024 * 1024,
+		}
+
+		for input, expected := range tests {
+			size, parseErr := yamlconfig.ParseByteSize(input)
+			require.NoError(t, parseErr, input)
+			require.Equal(t, expected, size, input)
+		}
+	})
+
+	t.Run("Parse Invalid Byte Size", func(t *testing.T) {
+		for _, input := range []string{"", "MB", "-1KB", "1XB", "99999999999TiB"} {
+			_, parseErr := yamlconfig.ParseByteSize(input)
+			require.Error(t, parseErr, input)
+		}
+	})
+
+	t.Run("Byte Size String", func(t *testing.T) {
+		require.Equal(t, "1MiB", yamlconfig.ByteSize(1<<20).String())
+		require.Equal(t, "1TB", yamlconfig.ByteSize(1e12).String())
+		require.Equal(t, "1500B", yamlconfig.ByteSize(1500).String())
+	})
+
+	t.Run("Byte Size Decode", func(t *testing.T) {
+		cfg := struct {
+			Limit yamlconfig.ByteSize `yaml:"limit"`
+		}{}
+		path := writeTempConfig(t, "limit: 64MiB\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, yamlconfig.ByteSize(64<<20), cfg.Limit)
+	})
+}
@@ -0,0 +1,40 @@
+package yamlconfig
+
+import "bytes"
+
+// Canonicalize loads and validates a configuration file, with defaults
+// applied, and returns it re-marshalled as YAML. The output always lists keys
+// in struct field order with consistent formatting, so two files describing
+// the same configuration produce identical output regardless of how they were
+// written.
+//
+// Parameters:
+//
+// path: The path to the configuration file.
+// config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
+//
+// Returns:
+// []byte: The canonical YAML document.
+// error: An error if the configuration file could not be loaded, decoded or
+// validated.
+//
+// Example:
+//
+// out, err := yamlconfig.Canonicalize("config.yml", &cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func Canonicalize(path string, config interface{}, opts ...Option) ([]byte, error) {
+	if loadErr := LoadConfig(path, config, opts...); loadErr != nil {
+		return nil, loadErr
+	}
+
+	var buf bytes.Buffer
+	if writeErr := WriteConfig(&buf, config); writeErr != nil {
+		return nil, writeErr
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,27 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	t.Run("Canonicalize Orders Keys And Applies Defaults", func(t *testing.T) {
+		cfg := TestConfigDefaults{}
+		path := writeTempConfig(t, "server: {host: example.com}\nname:   app\n")
+
+		out, canonicalizeErr := yamlconfig.Canonicalize(path, &cfg)
+		require.NoError(t, canonicalizeErr)
+		require.Equal(t, "name: app\nport: 8080\ntimeout: 5s\nmethods:\n    - GET\n    - HEAD\nserver:\n    host: example.com\n", string(out))
+	})
+
+	t.Run("Canonicalize Invalid Config", func(t *testing.T) {
+		cfg := TestConfigDefaults{}
+		path := writeTempConfig(t, "port: 1\n")
+
+		_, canonicalizeErr := yamlconfig.Canonicalize(path, &cfg)
+		require.Error(t, canonicalizeErr)
+	})
+}
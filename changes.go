@@ -0,0 +1,141 @@
+package yamlconfig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// changeSet holds the dotted paths at which two decoded documents differ.
+type changeSet map[string]bool
+
+// touches reports whether the config item at path was changed, lies within a
+// changed item or holds a changed item.
+func (c changeSet) touches(path string) bool {
+	for changed := range c {
+		if changed == "" || changed == path || isWithin(changed, path) || isWithin(path, changed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWithin reports whether the item at path lies beneath the item at parent.
+func isWithin(path, parent string) bool {
+	if parent == "" {
+		return path != ""
+	}
+
+	return strings.HasPrefix(path, parent+".") || strings.HasPrefix(path, parent+"[")
+}
+
+// diffDocuments returns the paths at which the documents old and updated
+// differ. It reports false when the documents use merge keys, whose values do
+// not map onto config paths, and the changes cannot be trusted.
+func diffDocuments(old, updated *yaml.Node) (changeSet, bool) {
+	changes := changeSet{}
+	if !diffNodes(resolveNode(old), resolveNode(updated), "", changes) {
+		return nil, false
+	}
+
+	return changes, true
+}
+
+// diffNodes records in changes the paths beneath path at which the nodes old
+// and updated differ, reporting false if a merge key is found.
+func diffNodes(old, updated *yaml.Node, path string, changes changeSet) bool {
+	if old == nil || updated == nil || old.Kind != updated.Kind || old.ShortTag() != updated.ShortTag() {
+		changes[path] = true
+
+		return true
+	}
+
+	switch old.Kind { //nolint:exhaustive // Document and alias nodes are resolved by the caller
+	case yaml.MappingNode:
+		if hasMergeKeys(old) || hasMergeKeys(updated) {
+			return false
+		}
+
+		for i := 0; i+1 < len(old.Content); i += 2 {
+			key := old.Content[i].Value
+			if !diffNodes(resolveNode(old.Content[i+1]), mappingValue(updated, key), joinPath(path, key), changes) {
+				return false
+			}
+		}
+
+		// Keys that were added have no old value to compare against
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			if key := updated.Content[i].Value; mappingValue(old, key) == nil {
+				changes[joinPath(path, key)] = true
+			}
+		}
+	case yaml.SequenceNode:
+		if len(old.Content) != len(updated.Content) {
+			changes[path] = true
+
+			return true
+		}
+
+		for i := range old.Content {
+			if !diffNodes(resolveNode(old.Content[i]), resolveNode(updated.Content[i]), path+"["+strconv.Itoa(i)+"]", changes) {
+				return false
+			}
+		}
+	default:
+		if old.Value != updated.Value {
+			changes[path] = true
+		}
+	}
+
+	return true
+}
+
+// hasMergeKeys reports whether node is a mapping holding a "<<" merge key.
+func hasMergeKeys(node *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "<<" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dependsOnDocumentOnly reports whether the values decoded into typ are fully
+// determined by the document. Fields read from other files or the environment
+// and types that post-process themselves can change without the document
+// changing, so their configs are always validated in full. The seen map guards
+// against recursive types.
+func dependsOnDocumentOnly(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if typ.Implements(afterDecoderType) || reflect.PointerTo(typ).Implements(afterDecoderType) {
+		return false
+	}
+
+	typ = derefType(typ)
+	if seen[typ] {
+		return true
+	}
+
+	seen[typ] = true
+
+	switch typ.Kind() { //nolint:exhaustive // Only containers can hold tagged fields
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if parseTag(field.Tag.Get("yamlconfig")).has("fromfile") || field.Tag.Get("env") != "" || !dependsOnDocumentOnly(field.Type, seen) {
+				return false
+			}
+		}
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return dependsOnDocumentOnly(typ.Elem(), seen)
+	}
+
+	return true
+}
@@ -0,0 +1,85 @@
+package yamlconfig
+
+import "reflect"
+
+// Clone returns a deep copy of a configuration. Pointers, slices, maps and
+// interfaces are copied recursively, so the clone can be modified, for example
+// by ApplyPatch, without affecting the original. This allows transactional
+// reloads: clone, apply and validate, then either keep or discard the clone.
+// Unexported fields are copied shallowly.
+//
+// Example:
+//
+// next := yamlconfig.Clone(&cfg)
+//
+//	if err := yamlconfig.ApplyPatch(next, patch); err == nil {
+//	    cfg = *next
+//	}
+func Clone[T any](config T) T {
+	src := reflect.ValueOf(&config).Elem()
+	dst := reflect.New(src.Type()).Elem()
+
+	deepCopy(dst, src)
+
+	clone, _ := dst.Interface().(T)
+
+	return clone
+}
+
+// deepCopy copies src into dst, allocating new pointers, slices and maps.
+func deepCopy(dst, src reflect.Value) {
+	switch src.Kind() { //nolint:exhaustive // Remaining kinds are copied by value
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+
+		dst.Set(reflect.New(src.Type().Elem()))
+		deepCopy(dst.Elem(), src.Elem())
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		value := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(value, src.Elem())
+		dst.Set(value)
+	case reflect.Struct:
+		dst.Set(src)
+
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				deepCopy(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+
+		iter := src.MapRange()
+		for iter.Next() {
+			value := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(value, iter.Value())
+			dst.SetMapIndex(iter.Key(), value)
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,66 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigClone struct {
+	Name     string                       `yaml:"name"`
+	Tags     []string                     `yaml:"tags"`
+	Labels   map[string]string            `yaml:"labels"`
+	Backends map[string]*TestCloneBackend `yaml:"backends"`
+	Extra    interface{}                  `yaml:"extra"`
+	Database *TestCloneBackend            `yaml:"database"`
+}
+
+type TestCloneBackend struct {
+	Hosts []string `yaml:"hosts"`
+}
+
+func TestClone(t *testing.T) {
+	t.Run("Clone Is Independent", func(t *testing.T) {
+		original := &TestConfigClone{
+			Name:     "app",
+			Tags:     []string{"a"},
+			Labels:   map[string]string{"k": "v"},
+			Backends: map[string]*TestCloneBackend{"primary": {Hosts: []string{"h1"}}},
+			Extra:    []interface{}{"x"},
+			Database: &TestCloneBackend{Hosts: []string{"db"}},
+		}
+
+		clone := yamlconfig.Clone(original)
+		require.Equal(t, original, clone)
+
+		clone.Tags[0] = "b"
+		clone.Labels["k"] = "changed"
+		clone.Backends["primary"].Hosts[0] = "h2"
+		clone.Extra.([]interface{})[0] = "y"
+		clone.Database.Hosts = append(clone.Database.Hosts, "db2")
+
+		require.Equal(t, []string{"a"}, original.Tags)
+		require.Equal(t, "v", original.Labels["k"])
+		require.Equal(t, []string{"h1"}, original.Backends["primary"].Hosts)
+		require.Equal(t, []interface{}{"x"}, original.Extra)
+		require.Equal(t, []string{"db"}, original.Database.Hosts)
+	})
+
+	t.Run("Clone Struct Value", func(t *testing.T) {
+		original := TestConfigClone{Tags: []string{"a"}}
+
+		clone := yamlconfig.Clone(original)
+		clone.Tags[0] = "b"
+
+		require.Equal(t, []string{"a"}, original.Tags)
+	})
+
+	t.Run("Clone For Transactional Patch", func(t *testing.T) {
+		cfg := TestConfigEmpty{String: "test"}
+
+		next := yamlconfig.Clone(&cfg)
+		require.Error(t, yamlconfig.ApplyPatch(next, []byte("string: \"\"\n")))
+		require.Equal(t, "test", cfg.String)
+	})
+}
@@ -0,0 +1,161 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithCoerceStrings accepts quoted scalars for numeric and boolean fields, for
+// files from generators and templating tools that quote every value. Before
+// decoding, a quoted value such as "8080" destined for an int, uint or float
+// field is treated as a number, and a quoted "true" or "false" destined for a
+// bool field as a boolean. A quoted value that is not a valid number or
+// boolean fails loading with its line and path. Fields of types that decode
+// themselves, such as time.Duration and ByteSize, are left untouched.
+func WithCoerceStrings() Option {
+	return func(o *options) {
+		o.coerceStrings = true
+	}
+}
+
+// durationType is the reflect.Type of time.Duration, which is decoded from
+// strings such as "5s" despite being an integer.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// unmarshalerType is the reflect.Type of the yaml.Unmarshaler interface.
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// coerceStrings retags the quoted scalars of node that are decoded into
+// numeric or boolean fields of typ, so the decoder reads them as numbers and
+// booleans. The path is the dotted path of node within the document.
+func coerceStrings(node *yaml.Node, typ reflect.Type, path string) error {
+	node = resolveNode(node)
+	typ = derefType(typ)
+
+	if node == nil || typ.Implements(unmarshalerType) || reflect.PointerTo(typ).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch node.Kind { //nolint:exhaustive // Document and alias nodes are resolved above
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+
+			var valueType reflect.Type
+
+			switch typ.Kind() { //nolint:exhaustive // Only structs and maps hold mappings
+			case reflect.Struct:
+				field, ok := structFieldByKey(typ, key)
+				if !ok {
+					continue
+				}
+
+				valueType = field.Type
+			case reflect.Map:
+				valueType = typ.Elem()
+			default:
+				return nil
+			}
+
+			if err := coerceStrings(node.Content[i+1], valueType, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return nil
+		}
+
+		for i, item := range node.Content {
+			if err := coerceStrings(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		return coerceScalar(node, typ, path)
+	}
+
+	return nil
+}
+
+// coerceScalar retags a single quoted scalar decoded into a field of type typ.
+func coerceScalar(node *yaml.Node, typ reflect.Type, path string) error {
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 || typ == durationType {
+		return nil
+	}
+
+	var (
+		tag string
+		err error
+	)
+
+	value := strings.TrimSpace(node.Value)
+
+	switch typ.Kind() { //nolint:exhaustive // Only numeric and boolean kinds are coerced
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		tag = "!!int"
+		_, err = strconv.ParseInt(value, 0, typ.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		tag = "!!int"
+		_, err = strconv.ParseUint(value, 0, typ.Bits())
+	case reflect.Float32, reflect.Float64:
+		tag = "!!float"
+		_, err = strconv.ParseFloat(value, typ.Bits())
+	case reflect.Bool:
+		tag = "!!bool"
+		if !strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+			err = strconv.ErrSyntax
+		}
+
+		value = strings.ToLower(value)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("line %d: cannot coerce %q into %s for %s", node.Line, node.Value, typ, path)
+	}
+
+	node.Tag = tag
+	node.Value = value
+	node.Style = 0
+
+	return nil
+}
+
+// structFieldByKey returns the field of the struct type typ that is decoded
+// from the given YAML key, descending into inlined structs. An inlined map
+// holds the keys left over by the other fields, so it is skipped.
+func structFieldByKey(typ reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, inline, skip := yamlKey(field)
+		if skip {
+			continue
+		}
+
+		if inline {
+			if derefType(field.Type).Kind() != reflect.Struct {
+				continue
+			}
+
+			if found, ok := structFieldByKey(derefType(field.Type), key); ok {
+				return found, true
+			}
+
+			continue
+		}
+
+		if name == key {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,74 @@
+package yamlconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigCoerce struct {
+	Port    int                `yaml:"port"`
+	Ratio   float64            `yaml:"ratio"`
+	Workers uint8              `yaml:"workers"`
+	Debug   bool               `yaml:"debug" yamlconfig:"omitempty"`
+	Timeout time.Duration      `yaml:"timeout"`
+	Name    string             `yaml:"name"`
+	Ports   []int              `yaml:"ports"`
+	Limits  map[string]float64 `yaml:"limits"`
+}
+
+func TestCoerceStrings(t *testing.T) {
+	content := "port: \"8080\"\nratio: '0.5'\nworkers: \"4\"\ndebug: \"TRUE\"\ntimeout: \"5s\"\nname: \"123\"\nports: [\"80\", 443]\nlimits:\n  cpu: \"1.5\"\n"
+
+	t.Run("Quoted Scalars Fail By Default", func(t *testing.T) {
+		cfg := TestConfigCoerce{}
+		path := writeTempConfig(t, content)
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "cannot unmarshal !!str `8080` into int")
+	})
+
+	t.Run("With Coerce Strings", func(t *testing.T) {
+		cfg := TestConfigCoerce{}
+		path := writeTempConfig(t, content)
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithCoerceStrings()))
+		require.Equal(t, 8080, cfg.Port)
+		require.Equal(t, 0.5, cfg.Ratio)
+		require.Equal(t, uint8(4), cfg.Workers)
+		require.True(t, cfg.Debug)
+		require.Equal(t, 5*time.Second, cfg.Timeout)
+		require.Equal(t, "123", cfg.Name)
+		require.Equal(t, []int{80, 443}, cfg.Ports)
+		require.Equal(t, map[string]float64{"cpu": 1.5}, cfg.Limits)
+	})
+
+	t.Run("Value Cannot Be Coerced", func(t *testing.T) {
+		tests := map[string]string{
+			"port: \"80a\"\n":     "line 1: cannot coerce \"80a\" into int for port",
+			"workers: \"300\"\n":  "line 1: cannot coerce \"300\" into uint8 for workers",
+			"debug: \"yes\"\n":    "line 1: cannot coerce \"yes\" into bool for debug",
+			"ports: [1, \"x\"]\n": "line 1: cannot coerce \"x\" into int for ports[1]",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigCoerce{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithCoerceStrings()), expected)
+		}
+	})
+
+	t.Run("Inline Map Holds Undeclared Keys", func(t *testing.T) {
+		cfg := struct {
+			Port  int               `yaml:"port"`
+			Extra map[string]string `yaml:",inline"`
+		}{}
+		path := writeTempConfig(t, "port: \"8080\"\nregion: eu\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithCoerceStrings()))
+		require.Equal(t, 8080, cfg.Port)
+		require.Equal(t, map[string]string{"region": "eu"}, cfg.Extra)
+	})
+}
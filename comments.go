@@ -0,0 +1,91 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExtractComments reads the configuration file at path and returns the
+// comments written on the lines before each key, or before each item of a
+// sequence, by the dotted path of the item. The "#" marking each comment line
+// is removed, and the lines of a multi-line comment are joined with newlines.
+// Items without a comment are left out. It is intended for tools that show
+// operator-written documentation alongside the values.
+//
+// Parameters:
+//
+// path: The path to the configuration file, or "-" for standard input.
+//
+// Returns:
+// map[string]string: The comment of each commented item by its dotted path.
+// error: An error if the file could not be read or parsed.
+//
+// Example:
+//
+//	comments, err := yamlconfig.ExtractComments("config.yml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	fmt.Println(comments["server.port"]) // Port the API listens on.
+func ExtractComments(path string) (map[string]string, error) {
+	data, fileErr := readConfigFile(path)
+	if fileErr != nil {
+		return nil, fmt.Errorf("failed to load config file: %w", fileErr)
+	}
+
+	var doc yaml.Node
+	if yamlUnmarshalErr := yaml.Unmarshal(data, &doc); yamlUnmarshalErr != nil {
+		return nil, decodeError(data, yamlUnmarshalErr)
+	}
+
+	comments := map[string]string{}
+	if len(doc.Content) > 0 {
+		collectComments(doc.Content[0], "", comments)
+	}
+
+	return comments, nil
+}
+
+// collectComments records the head comment of every key and sequence item
+// beneath node.
+func collectComments(node *yaml.Node, path string, comments map[string]string) {
+	switch node.Kind { //nolint:exhaustive // Only collections hold commented items
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+
+			if comment := commentText(key.HeadComment); comment != "" {
+				comments[keyPath] = comment
+			}
+
+			collectComments(value, keyPath, comments)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+
+			if comment := commentText(item.HeadComment); comment != "" {
+				comments[itemPath] = comment
+			}
+
+			collectComments(item, itemPath, comments)
+		}
+	}
+}
+
+// commentText removes the "#" marker, and the space after it, from each line
+// of a comment.
+func commentText(comment string) string {
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "#")
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
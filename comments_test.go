@@ -0,0 +1,33 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractComments(t *testing.T) {
+	t.Run("Head Comments By Path", func(t *testing.T) {
+		path := writeTempConfig(t, "# Name of the service.\nname: api\nserver:\n  # Port the API listens on.\n  # Must be above 1024.\n  port: 8080\n  host: 0.0.0.0 # not a head comment\nreplicas:\n  # Primary region.\n  - eu\n  - us\n")
+
+		comments, err := yamlconfig.ExtractComments(path)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"name":        "Name of the service.",
+			"server.port": "Port the API listens on.\nMust be above 1024.",
+			"replicas[0]": "Primary region.",
+		}, comments)
+	})
+
+	t.Run("Empty File", func(t *testing.T) {
+		comments, err := yamlconfig.ExtractComments(writeTempConfig(t, ""))
+		require.NoError(t, err)
+		require.Empty(t, comments)
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		_, err := yamlconfig.ExtractComments("nonexistent.yml")
+		require.ErrorContains(t, err, "failed to load config file")
+	})
+}
@@ -0,0 +1,84 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ValidateComplete checks that a configuration is fully populated: every leaf
+// field, at any depth, must hold a non-zero value. Only fields explicitly
+// tagged yamlconfig:"omitempty", and fields the YAML decoder skips, are
+// exempt. Nil pointers to structs count as missing. Unlike regular validation
+// every missing leaf is reported, together in a MultiError.
+//
+// Parameters:
+//
+// config: A pointer to the configuration struct.
+//
+// Returns:
+// error: A MultiError listing every missing leaf, or nil if the configuration
+// is complete.
+//
+// Example:
+//
+//	if err := yamlconfig.ValidateComplete(&cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+func ValidateComplete(config interface{}) error {
+	val := reflect.ValueOf(config)
+
+	// Check if the config is a pointer and points to a struct
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, please ensure the input is a struct pointer")
+	}
+
+	var errs []error
+
+	collectMissingLeaves(val.Elem(), "", &errs)
+
+	if len(errs) > 0 {
+		return &MultiError{Errors: errs}
+	}
+
+	return nil
+}
+
+// collectMissingLeaves appends a ValidationError for every empty leaf field of
+// the struct val, descending into nested structs and struct pointers.
+func collectMissingLeaves(val reflect.Value, path string, errs *[]error) {
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		typ := val.Type().Field(i)
+
+		key, inline, skip := yamlKey(typ)
+		if skip || parseTag(typ.Tag.Get("yamlconfig")).has("omitempty") {
+			continue
+		}
+
+		fieldPath := path
+		if !inline {
+			fieldPath = joinPath(path, key)
+		}
+
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+			if field.IsNil() {
+				*errs = append(*errs, &ValidationError{Path: fieldPath, Message: "missing config item"})
+
+				continue
+			}
+
+			field = field.Elem()
+		}
+
+		// A yaml.Node is a leaf holding a raw section of the document
+		if field.Kind() == reflect.Struct && field.Type() != nodeType {
+			collectMissingLeaves(field, fieldPath, errs)
+
+			continue
+		}
+
+		if isEmpty(field) {
+			*errs = append(*errs, &ValidationError{Path: fieldPath, Message: "missing config item"})
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type TestConfigComplete struct {
+	Name   string `yaml:"name"`
+	Notes  string `yaml:"notes" yamlconfig:"omitempty"`
+	Server struct {
+		Address string `yaml:"address"`
+		Port    int    `yaml:"port"`
+	} `yaml:"server"`
+	TLS *struct {
+		Cert string `yaml:"cert"`
+	} `yaml:"tls"`
+}
+
+func TestValidateComplete(t *testing.T) {
+	t.Run("Validate Complete Reports Every Missing Leaf", func(t *testing.T) {
+		cfg := TestConfigComplete{Name: "app"}
+		cfg.Server.Address = "localhost"
+
+		validateErr := yamlconfig.ValidateComplete(&cfg)
+
+		var multiErr *yamlconfig.MultiError
+		require.True(t, errors.As(validateErr, &multiErr))
+		require.EqualError(t, multiErr, "server.port: missing config item; tls: missing config item")
+	})
+
+	t.Run("Validate Complete Populated", func(t *testing.T) {
+		cfg := TestConfigComplete{Name: "app"}
+		cfg.Server.Address = "localhost"
+		cfg.Server.Port = 80
+		cfg.TLS = &struct {
+			Cert string `yaml:"cert"`
+		}{Cert: "cert.pem"}
+
+		require.NoError(t, yamlconfig.ValidateComplete(&cfg))
+	})
+
+	t.Run("Validate Complete Requires Struct Pointer", func(t *testing.T) {
+		require.Error(t, yamlconfig.ValidateComplete(TestConfigComplete{}))
+	})
+
+	t.Run("Validate Complete Raw Node Is A Leaf", func(t *testing.T) {
+		cfg := TestConfigRawSection{Kind: "bucket"}
+		require.EqualError(t, yamlconfig.ValidateComplete(&cfg), "spec: missing config item")
+
+		require.NoError(t, yaml.Unmarshal([]byte("name: logs\n"), &cfg.Spec))
+		require.NoError(t, yamlconfig.ValidateComplete(&cfg))
+	})
+}
@@ -0,0 +1,68 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SplitCSV splits a comma-separated string into its trimmed elements, in the
+// same way as fields tagged yamlconfig:"csv" are split for validation. An
+// empty or blank string has no elements.
+//
+// Example:
+//
+//	recipients := yamlconfig.SplitCSV(cfg.Recipients) // []string{"a@x.com", "b@y.com"}
+func SplitCSV(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+
+	elements := strings.Split(s, ",")
+	for i, element := range elements {
+		elements[i] = strings.TrimSpace(element)
+	}
+
+	return elements
+}
+
+// validateElements applies the other rules of the tag to each element of a
+// csv tagged field: the comma-separated items of a string field, or the items
+// of a string slice field. The unique rule applies to the elements as a whole.
+func validateElements(field reflect.Value, tag fieldTag) error {
+	var elements []string
+
+	switch {
+	case field.Kind() == reflect.String:
+		elements = SplitCSV(field.String())
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < field.Len(); i++ {
+			elements = append(elements, field.Index(i).String())
+		}
+	default:
+		return fmt.Errorf("csv is only supported on string and string slice fields")
+	}
+
+	elementTag := fieldTag{}
+	for name, value := range tag {
+		if name != "unique" {
+			elementTag[name] = value
+		}
+	}
+
+	for i, element := range elements {
+		if element == "" {
+			return fmt.Errorf("element %d is empty", i)
+		}
+
+		if err := applyRules(reflect.ValueOf(element), elementTag); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	if tag.has("unique") {
+		return validateUnique(reflect.ValueOf(elements), "")
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigCSV struct {
+	Recipients string   `yaml:"recipients" yamlconfig:"csv,format=email"`
+	Levels     []string `yaml:"levels" yamlconfig:"omitempty,csv,oneof=debug info"`
+}
+
+func TestCSV(t *testing.T) {
+	t.Run("Valid Elements", func(t *testing.T) {
+		cfg := TestConfigCSV{}
+		path := writeTempConfig(t, "recipients: a@x.com, b@y.com\nlevels: [debug, info]\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, []string{"a@x.com", "b@y.com"}, yamlconfig.SplitCSV(cfg.Recipients))
+	})
+
+	t.Run("Invalid Elements", func(t *testing.T) {
+		tests := map[string]string{
+			"recipients: a@x.com,b@\n":                      "recipients: element 1: value \"b@\" is not a valid email address",
+			"recipients: a@x.com,,b@y.com\n":                "recipients: element 1 is empty",
+			"recipients: a@x.com\nlevels: [debug, trace]\n": "levels: element 1: value trace must be one of: debug info",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigCSV{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+		}
+	})
+
+	t.Run("Split CSV", func(t *testing.T) {
+		require.Equal(t, []string{"a", "b"}, yamlconfig.SplitCSV(" a , b "))
+		require.Nil(t, yamlconfig.SplitCSV("  "))
+	})
+}
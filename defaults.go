@@ -0,0 +1,145 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultFactories holds the functions registered with RegisterDefault.
+var defaultFactories = struct {
+	sync.RWMutex
+	m map[reflect.Type]func() interface{}
+}{m: map[reflect.Type]func() interface{}{}}
+
+// RegisterDefault registers a function that builds the default value for
+// fields of type t. It covers defaults that cannot be written in a
+// yamlconfig:"default=value" tag, such as pre-populated maps. During loading,
+// any empty field of type t without a default tag is set to the factory's
+// result, which must be assignable to t.
+//
+// Example:
+//
+//	yamlconfig.RegisterDefault(reflect.TypeOf(Headers{}), func() interface{} {
+//	    return Headers{"User-Agent": "app"}
+//	})
+func RegisterDefault(t reflect.Type, factory func() interface{}) {
+	defaultFactories.Lock()
+	defer defaultFactories.Unlock()
+
+	defaultFactories.m[t] = factory
+}
+
+// defaultFactory returns the factory registered for type t, if any.
+func defaultFactory(t reflect.Type) (func() interface{}, bool) {
+	defaultFactories.RLock()
+	defer defaultFactories.RUnlock()
+
+	factory, ok := defaultFactories.m[t]
+
+	return factory, ok
+}
+
+// applyDefaults sets every empty field tagged yamlconfig:"default=value"
+// whose key is absent from node, the mapping val was decoded from, to its
+// default value. A key written in the document keeps its value even when it
+// is the zero value, so "enabled: false" is not replaced by default=true, and
+// a key holding null counts as absent. Scalar defaults are decoded as YAML
+// into the field's type, so durations such as "5s" are supported. Defaults for
+// slices are given as space separated items, since commas separate the
+// options of the tag. Empty fields without a default tag whose type has a
+// factory registered with RegisterDefault are set to the factory's result.
+// Invalid defaults are reported to errs. The path is the dotted path of val.
+func applyDefaults(val reflect.Value, node *yaml.Node, path string, errs *errorList) error {
+	val = indirect(val)
+	if val.IsValid() && val.Kind() == reflect.Map {
+		return walkMapValues(val, func(key string, value reflect.Value) error {
+			return applyDefaults(value, mappingValue(node, key), joinPath(path, key), errs)
+		})
+	}
+
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		typ := val.Type().Field(i)
+
+		key, inline, skip := yamlKey(typ)
+		if skip {
+			continue
+		}
+
+		if inline {
+			if err := applyDefaults(field, node, path, errs); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		fieldPath := joinPath(path, key)
+		fieldNode := mappingValue(node, key)
+
+		if isEmpty(field) && (fieldNode == nil || fieldNode.ShortTag() == "!!null") {
+			if err := applyDefault(field, typ, fieldPath, errs); err != nil {
+				return err
+			}
+		}
+
+		if err := applyDefaults(field, fieldNode, fieldPath, errs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyDefault sets the empty field at path to the default from its tag, or
+// to the result of the factory registered for its type.
+func applyDefault(field reflect.Value, typ reflect.StructField, path string, errs *errorList) error {
+	if value, ok := parseTag(typ.Tag.Get("yamlconfig")).get("default"); ok {
+		if err := setDefault(field, value); err != nil {
+			return errs.add(fmt.Errorf("invalid default for %s: %w", path, err))
+		}
+
+		return nil
+	}
+
+	if factory, ok := defaultFactory(field.Type()); ok {
+		result := factory()
+
+		value := reflect.ValueOf(result)
+		if !value.IsValid() || !value.Type().AssignableTo(field.Type()) {
+			return errs.add(fmt.Errorf("invalid default for %s: factory for %s returned %T", path, field.Type(), result))
+		}
+
+		field.Set(value)
+	}
+
+	return nil
+}
+
+// setDefault decodes the default value into the field.
+func setDefault(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Slice {
+		items := strings.Fields(value)
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+
+		for i, item := range items {
+			if err := yaml.Unmarshal([]byte(item), slice.Index(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+
+		return nil
+	}
+
+	return yaml.Unmarshal([]byte(value), field.Addr().Interface())
+}
@@ -0,0 +1,160 @@
+package yamlconfig_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigDefaults struct {
+	Name    string        `yaml:"name"`
+	Port    int           `yaml:"port" yamlconfig:"default=8080"`
+	Timeout time.Duration `yaml:"timeout" yamlconfig:"default=5s"`
+	Methods []string      `yaml:"methods" yamlconfig:"default=GET HEAD"`
+	Server  struct {
+		Host string `yaml:"host" yamlconfig:"default=localhost"`
+	} `yaml:"server"`
+}
+
+type TestConfigZeroDefaults struct {
+	Enabled bool   `yaml:"enabled" yamlconfig:"default=true"`
+	Retries int    `yaml:"retries" yamlconfig:"default=3"`
+	Region  string `yaml:"region" yamlconfig:"default=eu"`
+}
+
+type TestHeaders map[string]string
+
+type TestAllowedMethods []string
+
+type TestConfigFactoryDefaults struct {
+	Headers TestHeaders        `yaml:"headers"`
+	Methods TestAllowedMethods `yaml:"methods" yamlconfig:"default=GET"`
+}
+
+type TestServiceConfig struct {
+	Host    string `yaml:"host"`
+	Port    int    `yaml:"port" yamlconfig:"default=80"`
+	Timeout string `yaml:"timeout" yamlconfig:"default=5s,oneof=5s 10s"`
+}
+
+type TestConfigServices struct {
+	Services map[string]TestServiceConfig  `yaml:"services"`
+	Backends map[string]*TestServiceConfig `yaml:"backends" yamlconfig:"omitempty"`
+}
+
+func TestDefaults(t *testing.T) {
+	t.Run("Defaults Applied To Empty Fields", func(t *testing.T) {
+		cfg := TestConfigDefaults{}
+		path := writeTempConfig(t, "name: app\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, 8080, cfg.Port)
+		require.Equal(t, 5*time.Second, cfg.Timeout)
+		require.Equal(t, []string{"GET", "HEAD"}, cfg.Methods)
+		require.Equal(t, "localhost", cfg.Server.Host)
+	})
+
+	t.Run("Defaults Do Not Override Values", func(t *testing.T) {
+		cfg := TestConfigDefaults{}
+		path := writeTempConfig(t, "name: app\nport: 9090\nmethods: [POST]\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, 9090, cfg.Port)
+		require.Equal(t, []string{"POST"}, cfg.Methods)
+	})
+
+	t.Run("Required Field Satisfied By Default", func(t *testing.T) {
+		cfg := struct {
+			Port    int    `yaml:"port" yamlconfig:"default=8080"`
+			Host    string `yaml:"host" yamlconfig:"default=localhost,notblank"`
+			Timeout string `yaml:"timeout" yamlconfig:"omitempty,default=5s"`
+		}{}
+		path := writeTempConfig(t, "host:\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, 8080, cfg.Port)
+		require.Equal(t, "localhost", cfg.Host)
+		require.Equal(t, "5s", cfg.Timeout)
+	})
+
+	t.Run("Default Checked By Rules", func(t *testing.T) {
+		cfg := struct {
+			Level string `yaml:"level" yamlconfig:"default=trace,oneof=debug info"`
+		}{}
+		path := writeTempConfig(t, "{}\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "level: value trace must be one of: debug info")
+	})
+
+	t.Run("Invalid Default", func(t *testing.T) {
+		cfg := struct {
+			Port int `yaml:"port" yamlconfig:"default=abc"`
+		}{}
+		path := writeTempConfig(t, "{}\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "invalid default for port")
+	})
+
+	t.Run("Registered Default Factory", func(t *testing.T) {
+		yamlconfig.RegisterDefault(reflect.TypeOf(TestHeaders{}), func() interface{} {
+			return TestHeaders{"User-Agent": "app"}
+		})
+		yamlconfig.RegisterDefault(reflect.TypeOf(TestAllowedMethods{}), func() interface{} {
+			return TestAllowedMethods{"POST"}
+		})
+
+		cfg := TestConfigFactoryDefaults{}
+		path := writeTempConfig(t, "{}\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, TestHeaders{"User-Agent": "app"}, cfg.Headers)
+		require.Equal(t, TestAllowedMethods{"GET"}, cfg.Methods)
+
+		cfg = TestConfigFactoryDefaults{}
+		path = writeTempConfig(t, "headers:\n  Accept: json\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, TestHeaders{"Accept": "json"}, cfg.Headers)
+	})
+
+	t.Run("Defaults Applied To Map Struct Values", func(t *testing.T) {
+		cfg := TestConfigServices{}
+		path := writeTempConfig(t, "services:\n  api:\n    host: api.local\n  web:\n    host: web.local\n    port: 8080\nbackends:\n  db:\n    host: db.local\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, TestServiceConfig{Host: "api.local", Port: 80, Timeout: "5s"}, cfg.Services["api"])
+		require.Equal(t, TestServiceConfig{Host: "web.local", Port: 8080, Timeout: "5s"}, cfg.Services["web"])
+		require.Equal(t, 80, cfg.Backends["db"].Port)
+	})
+
+	t.Run("Map Struct Values Validated With Key In Path", func(t *testing.T) {
+		cfg := TestConfigServices{}
+		path := writeTempConfig(t, "services:\n  api:\n    timeout: 1m\n  web:\n    host: web.local\nbackends:\n  db:\n    port: 5432\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: services.api.host: missing required config item; "+
+			"services.api.timeout: value 1m must be one of: 5s 10s; "+
+			"backends.db.host: missing required config item")
+	})
+
+	t.Run("Defaults Do Not Override Written Zero Values", func(t *testing.T) {
+		cfg := TestConfigZeroDefaults{}
+		path := writeTempConfig(t, "enabled: false\nretries: 0\nregion: \"\"\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.False(t, cfg.Enabled)
+		require.Equal(t, 0, cfg.Retries)
+		require.Empty(t, cfg.Region)
+	})
+
+	t.Run("Defaults Applied To Absent And Null Keys", func(t *testing.T) {
+		cfg := TestConfigZeroDefaults{}
+		path := writeTempConfig(t, "enabled: false\nretries: ~\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.False(t, cfg.Enabled)
+		require.Equal(t, 3, cfg.Retries)
+		require.Equal(t, "eu", cfg.Region)
+	})
+}
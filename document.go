@@ -0,0 +1,236 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// documentPass rewrites a parsed YAML document before it is decoded.
+type documentPass func(doc *yaml.Node) error
+
+// taggedRewrites lists the yamlconfig tag options that change how a value is
+// decoded, with the function rewriting the value's node before decoding.
+var taggedRewrites = []struct {
+	option string
+	fn     func(node *yaml.Node, typ reflect.Type, path string) error
+}{
+	{"unixtime", convertUnixTime},
+	{"base64", decodeBase64},
+}
+
+// documentPasses returns the document rewrites needed for data, in the order
+// they should run. The path is that of the file data was read from and the
+// type is that of the value the document will be decoded into.
+func (o *options) documentPasses(data []byte, path string, typ reflect.Type) []documentPass {
+	var passes []documentPass
+
+	if hasIncludes(data) {
+		passes = append(passes, func(doc *yaml.Node) error {
+			return expandIncludes(doc, includeDir(path), includeStack(path), o.addSource)
+		})
+	}
+
+	if o.expandEnv {
+		passes = append(passes, func(doc *yaml.Node) error {
+			if o.envOpen == "" || o.envClose == "" {
+				return fmt.Errorf("environment variable delimiters must not be empty")
+			}
+
+			return expandEnvNodes(doc, o.envOpen, o.envClose)
+		})
+	}
+
+	if o.firstKeyWins {
+		passes = append(passes, func(doc *yaml.Node) error {
+			removeDuplicateKeys(doc, "", func(key *yaml.Node, keyPath string) {
+				o.warn(path, Warning{Line: key.Line, Path: keyPath, Message: "duplicate key ignored, the first occurrence is used"})
+			})
+
+			return nil
+		})
+	}
+
+	if len(o.migrations) > 0 {
+		passes = append(passes, func(doc *yaml.Node) error {
+			return migrateDocument(doc, o.migrations, o.versionKey)
+		})
+	}
+
+	if o.coerceStrings && typ != nil {
+		passes = append(passes, func(doc *yaml.Node) error {
+			return coerceStrings(doc, typ, "")
+		})
+	}
+
+	// Rewrite the values of fields whose tags change how they are decoded
+	for _, rewrite := range taggedRewrites {
+		if typ != nil && hasTaggedFields(typ, rewrite.option, map[reflect.Type]bool{}) {
+			passes = append(passes, func(doc *yaml.Node) error {
+				return rewriteTaggedNodes(doc, typ, "", rewrite.option, rewrite.fn)
+			})
+		}
+	}
+
+	// Check scalar types once every other pass has retagged what it needs to
+	if o.strictTypes && typ != nil {
+		passes = append(passes, func(doc *yaml.Node) error {
+			return checkStrictTypes(doc, typ, "")
+		})
+	}
+
+	return passes
+}
+
+// hasTaggedFields reports whether typ, or any struct reachable from it, has a
+// field whose yamlconfig tag holds the named option. The seen map guards
+// against recursive types.
+func hasTaggedFields(typ reflect.Type, option string, seen map[reflect.Type]bool) bool {
+	typ = derefType(typ)
+	if seen[typ] {
+		return false
+	}
+
+	seen[typ] = true
+
+	switch typ.Kind() { //nolint:exhaustive // Only containers can hold tagged fields
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if parseTag(field.Tag.Get("yamlconfig")).has(option) || hasTaggedFields(field.Type, option, seen) {
+				return true
+			}
+		}
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return hasTaggedFields(typ.Elem(), option, seen)
+	}
+
+	return false
+}
+
+// rewriteTaggedNodes calls fn with each node of the document decoded into a
+// field of typ whose yamlconfig tag holds the named option, along with the
+// field's type and dotted path, so fn can rewrite the node before decoding.
+// The path is the dotted path of node within the document.
+func rewriteTaggedNodes(node *yaml.Node, typ reflect.Type, path, option string, fn func(node *yaml.Node, typ reflect.Type, path string) error) error {
+	node = resolveNode(node)
+	typ = derefType(typ)
+
+	if node == nil {
+		return nil
+	}
+
+	switch {
+	case node.Kind == yaml.MappingNode && typ.Kind() == reflect.Struct:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+
+			field, ok := structFieldByKey(typ, key)
+			if !ok {
+				continue
+			}
+
+			var err error
+			if parseTag(field.Tag.Get("yamlconfig")).has(option) {
+				err = fn(resolveNode(node.Content[i+1]), field.Type, joinPath(path, key))
+			} else {
+				err = rewriteTaggedNodes(node.Content[i+1], field.Type, joinPath(path, key), option, fn)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	case node.Kind == yaml.MappingNode && typ.Kind() == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if err := rewriteTaggedNodes(node.Content[i+1], typ.Elem(), joinPath(path, node.Content[i].Value), option, fn); err != nil {
+				return err
+			}
+		}
+	case node.Kind == yaml.SequenceNode && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array):
+		for i, item := range node.Content {
+			if err := rewriteTaggedNodes(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), option, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rewriteDocument parses data, applies each pass to the document tree in turn
+// and returns the rewritten document as YAML.
+func rewriteDocument(data []byte, passes []documentPass) ([]byte, error) {
+	var doc yaml.Node
+	if yamlUnmarshalErr := yaml.Unmarshal(data, &doc); yamlUnmarshalErr != nil {
+		return nil, decodeError(data, yamlUnmarshalErr)
+	}
+
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+
+	for _, pass := range passes {
+		if passErr := pass(&doc); passErr != nil {
+			return nil, fmt.Errorf("failed to decode config file: %w", passErr)
+		}
+	}
+
+	out, yamlMarshalErr := yaml.Marshal(&doc)
+	if yamlMarshalErr != nil {
+		return nil, fmt.Errorf("failed to decode config file: %w", yamlMarshalErr)
+	}
+
+	return out, nil
+}
+
+// WithFirstKeyWins keeps the first occurrence of a key that is repeated within
+// the same mapping and drops the later ones, instead of failing to decode. It
+// is a compatibility shim for files produced by generators that list a key
+// twice with the first occurrence acting as the default.
+func WithFirstKeyWins() Option {
+	return func(o *options) {
+		o.firstKeyWins = true
+	}
+}
+
+// removeDuplicateKeys removes every repeated key, and its value, from the
+// mappings in the tree, keeping the first occurrence. Each removed key is
+// passed to removed along with its dotted path.
+func removeDuplicateKeys(node *yaml.Node, path string, removed func(key *yaml.Node, path string)) {
+	switch node.Kind { //nolint:exhaustive // Only collections hold keys
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			removeDuplicateKeys(child, path, removed)
+		}
+	case yaml.MappingNode:
+		seen := map[string]bool{}
+		content := node.Content[:0]
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind == yaml.ScalarNode && key.Value != "<<" {
+				if seen[key.Value] {
+					removed(key, joinPath(path, key.Value))
+
+					continue
+				}
+
+				seen[key.Value] = true
+			}
+
+			content = append(content, key, node.Content[i+1])
+		}
+
+		node.Content = content
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			removeDuplicateKeys(node.Content[i+1], joinPath(path, node.Content[i].Value), removed)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			removeDuplicateKeys(item, fmt.Sprintf("%s[%d]", path, i), removed)
+		}
+	}
+}
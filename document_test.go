@@ -0,0 +1,26 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentPasses(t *testing.T) {
+	t.Run("Duplicate Keys Fail By Default", func(t *testing.T) {
+		cfg := TestConfigEmptyStruct{}
+		path := writeTempConfig(t, "string: first\nstring: second\nstruct:\n  string: a\n  int: 1\n")
+
+		require.Error(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("With First Key Wins", func(t *testing.T) {
+		cfg := TestConfigEmptyStruct{}
+		path := writeTempConfig(t, "string: first\nstring: second\nstruct:\n  string: a\n  int: 1\n  int: 2\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithFirstKeyWins()))
+		require.Equal(t, "first", cfg.String)
+		require.Equal(t, 1, cfg.Struct.Int)
+	})
+}
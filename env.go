@@ -0,0 +1,274 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithRequireEnv makes loading fail unless the environment variable named by
+// the env tag of every field is set, listing each one that is not. It suits
+// secret-injection setups where a value that was meant to come from the
+// environment must not silently fall back to the file.
+func WithRequireEnv() Option {
+	return func(o *options) {
+		o.requireEnv = true
+	}
+}
+
+// defaultEnvListSeparator and defaultEnvKeyValueSeparator split the value of
+// an environment variable bound to a slice or map field into items, and each
+// map item into its key and value.
+const (
+	defaultEnvListSeparator     = ","
+	defaultEnvKeyValueSeparator = "="
+)
+
+// WithEnvSeparators changes the separators used to split environment
+// variables bound to slice and map fields, for values whose items contain
+// commas or equals signs. The list separator splits the value into items and
+// the key-value separator splits each map item into its key and value. The
+// defaults are "," and "=", so HOSTS=a,b,c and LABELS=tier=web,zone=a work.
+//
+// Example:
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithEnvSeparators(";", ":"))
+func WithEnvSeparators(list, keyValue string) Option {
+	return func(o *options) {
+		o.envListSeparator = list
+		o.envKeyValueSeparator = keyValue
+	}
+}
+
+// CheckEnvTags checks that no environment variable is named by the env tag of
+// more than one field, for example env:"APP_PORT" on both server.port and
+// metrics.port, which would make one variable silently override both. It only
+// inspects the struct type, so it can run at startup or in a unit test.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+//
+// Returns:
+// error: An error naming every environment variable used by more than one
+// field along with those fields, or nil if every name is unique.
+//
+// Example:
+//
+//	if err := yamlconfig.CheckEnvTags(&Config{}); err != nil {
+//	    log.Fatal(err)
+//	}
+func CheckEnvTags(config interface{}) error {
+	typ := reflect.TypeOf(config)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct or a pointer to a struct")
+	}
+
+	var names []string
+
+	fields := map[string][]string{}
+
+	collectEnvTags(typ, "", map[reflect.Type]bool{}, func(name, path string) {
+		if _, ok := fields[name]; !ok {
+			names = append(names, name)
+		}
+
+		fields[name] = append(fields[name], path)
+	})
+
+	var collisions []error
+
+	for _, name := range names {
+		if len(fields[name]) > 1 {
+			collisions = append(collisions, fmt.Errorf("env var %s is used by more than one field: %s", name, strings.Join(fields[name], ", ")))
+		}
+	}
+
+	if len(collisions) > 0 {
+		return &MultiError{Errors: collisions}
+	}
+
+	return nil
+}
+
+// collectEnvTags calls fn with the env tag and dotted path of every field of
+// the struct type typ, found under prefix, that has an env tag. Nested and
+// inlined structs are descended into, except those of a type already being
+// descended into further up, so a type that refers to itself is only checked
+// once.
+func collectEnvTags(typ reflect.Type, prefix string, seen map[reflect.Type]bool, fn func(name, path string)) {
+	if seen[typ] {
+		return
+	}
+
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		key, inline, skip := yamlKey(field)
+		if skip {
+			continue
+		}
+
+		path := prefix
+		if !inline {
+			path = joinPath(prefix, key)
+		}
+
+		if name := field.Tag.Get("env"); name != "" {
+			fn(name, path)
+		}
+
+		if fieldType := derefType(field.Type); fieldType.Kind() == reflect.Struct {
+			collectEnvTags(fieldType, path, seen, fn)
+		}
+	}
+}
+
+// applyEnv overrides the value of every field tagged env:"NAME" with the
+// environment variable NAME when it is set. String fields take the value as
+// it is, slice and map fields split it with the list and key-value
+// separators, and other fields decode it as YAML, so "8080", "true" and "5s"
+// work as they do in the file. Values that do not decode are reported to errs,
+// as are unset variables when required is set.
+func applyEnv(val reflect.Value, required bool, listSep, keyValueSep string, errs *errorList) error {
+	var unset []string
+
+	walkErr := walkFields(val, "", func(field reflect.Value, typ reflect.StructField, path string) error {
+		name := typ.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			unset = append(unset, name)
+
+			return nil
+		}
+
+		if err := setEnvValue(field, value, listSep, keyValueSep); err != nil {
+			return errs.add(fmt.Errorf("invalid value for env var %s (%s): %w", name, path, err))
+		}
+
+		return nil
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+
+	if required && len(unset) > 0 {
+		return errs.add(fmt.Errorf("env vars are not set: %s", strings.Join(unset, ", ")))
+	}
+
+	return nil
+}
+
+// setEnvValue stores the value of an environment variable in the field. A
+// slice field takes the items of the value split by listSep, and a map field
+// the key-value pairs split from each item by keyValueSep, with every item,
+// key and value trimmed and decoded into its type in the same way as a scalar
+// field. An empty value empties the field. Values written as a YAML flow
+// sequence or mapping, and fields of types that decode themselves, are
+// decoded as YAML instead.
+func setEnvValue(field reflect.Value, value, listSep, keyValueSep string) error {
+	kind := field.Kind()
+	trimmed := strings.TrimSpace(value)
+
+	switch {
+	case decodesItself(field.Type()),
+		kind == reflect.Slice && (field.Type().Elem().Kind() == reflect.Uint8 || strings.HasPrefix(trimmed, "[")),
+		kind == reflect.Map && strings.HasPrefix(trimmed, "{"),
+		kind != reflect.Slice && kind != reflect.Map:
+		return setStringValue(field, value)
+	}
+
+	if listSep == "" || keyValueSep == "" {
+		return fmt.Errorf("env separators must not be empty")
+	}
+
+	var items []string
+	if trimmed != "" {
+		items = strings.Split(value, listSep)
+	}
+
+	if kind == reflect.Slice {
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+
+		for i, item := range items {
+			if err := setEnvItem(slice.Index(i), i, item); err != nil {
+				return err
+			}
+		}
+
+		field.Set(slice)
+
+		return nil
+	}
+
+	m := reflect.MakeMapWithSize(field.Type(), len(items))
+
+	for i, item := range items {
+		k, v, ok := strings.Cut(item, keyValueSep)
+		if !ok {
+			return fmt.Errorf("item %d (%q) is not a key%svalue pair", i, strings.TrimSpace(item), keyValueSep)
+		}
+
+		key := reflect.New(field.Type().Key()).Elem()
+		if err := setEnvItem(key, i, k); err != nil {
+			return err
+		}
+
+		if m.MapIndex(key).IsValid() {
+			return fmt.Errorf("item %d repeats the key %q", i, strings.TrimSpace(k))
+		}
+
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := setStringValue(elem, strings.TrimSpace(v)); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+
+		m.SetMapIndex(key, elem)
+	}
+
+	field.Set(m)
+
+	return nil
+}
+
+// setEnvItem stores the i-th item of a slice or map value, which must not be
+// blank, in the settable value dst.
+func setEnvItem(dst reflect.Value, i int, item string) error {
+	item = strings.TrimSpace(item)
+	if item == "" {
+		return fmt.Errorf("item %d is empty", i)
+	}
+
+	if err := setStringValue(dst, item); err != nil {
+		return fmt.Errorf("item %d: %w", i, err)
+	}
+
+	return nil
+}
+
+// setStringValue stores a value given as a string, such as an environment
+// variable or a command line override, in the field. Strings are stored as
+// they are, other values are parsed as YAML into the field's type.
+func setStringValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.String {
+		field.SetString(value)
+
+		return nil
+	}
+
+	return yaml.Unmarshal([]byte(value), field.Addr().Interface())
+}
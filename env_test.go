@@ -0,0 +1,168 @@
+package yamlconfig_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigEnvTags struct {
+	Name   string `yaml:"name" env:"APP_NAME"`
+	Server struct {
+		Port int `yaml:"port" env:"APP_PORT"`
+	} `yaml:"server"`
+	Metrics *struct {
+		Port int `yaml:"port" env:"APP_PORT"`
+	} `yaml:"metrics"`
+	Admin struct {
+		Name string `yaml:"name" env:"APP_NAME"`
+		Port int    `yaml:"port" env:"APP_PORT"`
+	} `yaml:"admin"`
+}
+
+type TestConfigEnvBinding struct {
+	Host    string        `yaml:"host" env:"TEST_YAMLCONFIG_HOST"`
+	Port    int           `yaml:"port" env:"TEST_YAMLCONFIG_PORT"`
+	Timeout time.Duration `yaml:"timeout" env:"TEST_YAMLCONFIG_TIMEOUT"`
+}
+
+type TestConfigEnvCollections struct {
+	Hosts   []string          `yaml:"hosts" env:"TEST_YAMLCONFIG_HOSTS" yamlconfig:"omitempty"`
+	Ports   []int             `yaml:"ports" env:"TEST_YAMLCONFIG_PORTS" yamlconfig:"omitempty"`
+	Labels  map[string]string `yaml:"labels" env:"TEST_YAMLCONFIG_LABELS" yamlconfig:"omitempty"`
+	Weights map[string]int    `yaml:"weights" env:"TEST_YAMLCONFIG_WEIGHTS" yamlconfig:"omitempty"`
+}
+
+func TestCheckEnvTags(t *testing.T) {
+	t.Run("Unique Env Tags", func(t *testing.T) {
+		require.NoError(t, yamlconfig.CheckEnvTags(&TestConfigStruct{}))
+		require.NoError(t, yamlconfig.CheckEnvTags(struct {
+			Host string `yaml:"host" env:"HOST"`
+			Port int    `yaml:"port" env:"PORT"`
+		}{}))
+	})
+
+	t.Run("Colliding Env Tags", func(t *testing.T) {
+		require.EqualError(t, yamlconfig.CheckEnvTags(&TestConfigEnvTags{}),
+			"env var APP_NAME is used by more than one field: name, admin.name; "+
+				"env var APP_PORT is used by more than one field: server.port, metrics.port, admin.port")
+	})
+
+	t.Run("Not A Struct", func(t *testing.T) {
+		require.Error(t, yamlconfig.CheckEnvTags("config"))
+	})
+
+	t.Run("Recursive Type", func(t *testing.T) {
+		type node struct {
+			Name string `yaml:"name" env:"NODE_NAME"`
+			Next *node  `yaml:"next"`
+		}
+
+		require.NoError(t, yamlconfig.CheckEnvTags(&node{}))
+		require.EqualError(t, yamlconfig.CheckEnvTags(&struct {
+			Head node `yaml:"head"`
+			Tail node `yaml:"tail"`
+		}{}), "env var NODE_NAME is used by more than one field: head.name, tail.name")
+	})
+}
+
+func TestEnvBinding(t *testing.T) {
+	t.Run("Env Vars Override File Values", func(t *testing.T) {
+		t.Setenv("TEST_YAMLCONFIG_HOST", "env.local")
+		t.Setenv("TEST_YAMLCONFIG_PORT", "9090")
+
+		cfg := TestConfigEnvBinding{}
+		path := writeTempConfig(t, "host: file.local\nport: 80\ntimeout: 5s\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "env.local", cfg.Host)
+		require.Equal(t, 9090, cfg.Port)
+		require.Equal(t, 5*time.Second, cfg.Timeout)
+	})
+
+	t.Run("Invalid Env Var Value", func(t *testing.T) {
+		t.Setenv("TEST_YAMLCONFIG_PORT", "http")
+
+		cfg := TestConfigEnvBinding{}
+		path := writeTempConfig(t, "host: file.local\nport: 80\ntimeout: 5s\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: invalid value for env var TEST_YAMLCONFIG_PORT (port): ")
+	})
+
+	t.Run("Require Env Vars", func(t *testing.T) {
+		t.Setenv("TEST_YAMLCONFIG_HOST", "env.local")
+
+		cfg := TestConfigEnvBinding{}
+		path := writeTempConfig(t, "host: file.local\nport: 80\ntimeout: 5s\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithRequireEnv()),
+			"failed to load the config: env vars are not set: TEST_YAMLCONFIG_PORT, TEST_YAMLCONFIG_TIMEOUT")
+	})
+
+	t.Run("Env Vars Override Slices And Maps", func(t *testing.T) {
+		t.Setenv("TEST_YAMLCONFIG_HOSTS", "a, b,c")
+		t.Setenv("TEST_YAMLCONFIG_PORTS", "80,443")
+		t.Setenv("TEST_YAMLCONFIG_LABELS", "tier=web, zone=eu=1")
+		t.Setenv("TEST_YAMLCONFIG_WEIGHTS", "a=1,b=2")
+
+		cfg := TestConfigEnvCollections{}
+		path := writeTempConfig(t, "hosts: [file]\nlabels:\n  tier: db\n  owner: ops\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, []string{"a", "b", "c"}, cfg.Hosts)
+		require.Equal(t, []int{80, 443}, cfg.Ports)
+		require.Equal(t, map[string]string{"tier": "web", "zone": "eu=1"}, cfg.Labels)
+		require.Equal(t, map[string]int{"a": 1, "b": 2}, cfg.Weights)
+	})
+
+	t.Run("Env Vars Empty And YAML Collections", func(t *testing.T) {
+		t.Setenv("TEST_YAMLCONFIG_HOSTS", "")
+		t.Setenv("TEST_YAMLCONFIG_PORTS", "[80, 443]")
+		t.Setenv("TEST_YAMLCONFIG_LABELS", "{tier: web}")
+
+		cfg := TestConfigEnvCollections{}
+		path := writeTempConfig(t, "hosts: [file]\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Empty(t, cfg.Hosts)
+		require.Equal(t, []int{80, 443}, cfg.Ports)
+		require.Equal(t, map[string]string{"tier": "web"}, cfg.Labels)
+	})
+
+	t.Run("Env Var Separators", func(t *testing.T) {
+		t.Setenv("TEST_YAMLCONFIG_HOSTS", "a,1;b,2")
+		t.Setenv("TEST_YAMLCONFIG_LABELS", "url:http://x?a=b;tier:web")
+
+		cfg := TestConfigEnvCollections{}
+		path := writeTempConfig(t, "{}\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithEnvSeparators(";", ":")))
+		require.Equal(t, []string{"a,1", "b,2"}, cfg.Hosts)
+		require.Equal(t, map[string]string{"url": "http://x?a=b", "tier": "web"}, cfg.Labels)
+	})
+
+	t.Run("Malformed Slices And Maps", func(t *testing.T) {
+		tests := map[[2]string]string{
+			{"TEST_YAMLCONFIG_HOSTS", "a,,b"}:           "invalid value for env var TEST_YAMLCONFIG_HOSTS (hosts): item 1 is empty",
+			{"TEST_YAMLCONFIG_PORTS", "80,http"}:        "invalid value for env var TEST_YAMLCONFIG_PORTS (ports): item 1: yaml: unmarshal errors",
+			{"TEST_YAMLCONFIG_LABELS", "tier=web,zone"}: "invalid value for env var TEST_YAMLCONFIG_LABELS (labels): item 1 (\"zone\") is not a key=value pair",
+			{"TEST_YAMLCONFIG_LABELS", "a=1,a=2"}:       "invalid value for env var TEST_YAMLCONFIG_LABELS (labels): item 1 repeats the key \"a\"",
+			{"TEST_YAMLCONFIG_LABELS", "=web"}:          "invalid value for env var TEST_YAMLCONFIG_LABELS (labels): item 0 is empty",
+			{"TEST_YAMLCONFIG_WEIGHTS", "a=heavy"}:      "invalid value for env var TEST_YAMLCONFIG_WEIGHTS (weights): item 0: yaml: unmarshal errors",
+		}
+
+		for env, expected := range tests {
+			t.Setenv(env[0], env[1])
+
+			cfg := TestConfigEnvCollections{}
+			path := writeTempConfig(t, "{}\n")
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+			require.NoError(t, os.Unsetenv(env[0]))
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package yamlconfig
+
+import (
+	"errors"
+	"strings"
+)
+
+// ValidationError describes a config item that failed validation.
+type ValidationError struct {
+	// Path is the dotted path of the config item, made of YAML key names.
+	Path string
+	// Message describes why the config item is invalid.
+	Message string
+	// Value is the offending value, only set when WithVerboseErrors is used.
+	// Values of fields tagged yamlconfig:"secret" are masked.
+	Value string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	if e.Value != "" {
+		return e.Path + "=" + e.Value + ": " + e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// errorList gathers the errors found by a loading pass. In FailFast mode add
+// returns the error so the pass stops, in Collect mode the error is kept and
+// nil is returned so the pass carries on.
+type errorList struct {
+	// mode decides whether errors are returned or kept.
+	mode ErrorMode
+	// errs holds the errors kept in Collect mode.
+	errs []error
+}
+
+// add records err, returning it unchanged in FailFast mode and nil in
+// Collect mode.
+func (l *errorList) add(err error) error {
+	if err == nil || l.mode != Collect {
+		return err
+	}
+
+	l.errs = append(l.errs, err)
+
+	return nil
+}
+
+// err returns the kept errors as a MultiError, or nil if there are none.
+func (l *errorList) err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+
+	return &MultiError{Errors: l.errs}
+}
+
+// MultiError holds every error found when errors are collected rather than
+// returned as soon as the first one is found.
+type MultiError struct {
+	Errors []error
+}
+
+// Error implements the error interface, joining the messages of all errors.
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the collected errors, so errors.Is and errors.As look at
+// each of them. A single *ValidationError can be pulled out of the aggregate
+// with errors.As.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// FormatErrors renders the validation errors held by err as an indented tree
+// following the structure of the config, for command line tools to show
+// operators where in their file each problem is. Each failure is listed
+// under its parent item, in the order the failures were found. Errors that
+// are not a *ValidationError, such as a syntax error, are listed first as
+// they are.
+//
+// Example:
+//
+//	if err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect)); err != nil {
+//	    fmt.Fprint(os.Stderr, yamlconfig.FormatErrors(err))
+//	}
+//
+// Output:
+//
+//	database:
+//	  password: missing required config item
+//	  port: value 70000 exceeds max=65535
+//	name: missing required config item
+func FormatErrors(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var (
+		b    strings.Builder
+		root errorTreeNode
+	)
+
+	for _, e := range flattenErrors(err) {
+		var validationErr *ValidationError
+		if !errors.As(e, &validationErr) || validationErr.Path == "" {
+			b.WriteString(e.Error() + "\n")
+
+			continue
+		}
+
+		message := validationErr.Message
+		if validationErr.Value != "" {
+			message = "=" + validationErr.Value + ": " + message
+		} else {
+			message = ": " + message
+		}
+
+		root.add(splitErrorPath(validationErr.Path), message)
+	}
+
+	root.write(&b, "")
+
+	return b.String()
+}
+
+// flattenErrors returns the errors held by a MultiError within err, or err
+// alone if it does not hold one.
+func flattenErrors(err error) []error {
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, e := range multiErr.Errors {
+		errs = append(errs, flattenErrors(e)...)
+	}
+
+	return errs
+}
+
+// splitErrorPath splits a dotted config path into the names of the items
+// along it, keeping each sequence index as an item of its own, so
+// "servers[0].host" becomes "servers", "[0]" and "host".
+func splitErrorPath(path string) []string {
+	var names []string
+
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			continue
+		}
+
+		for {
+			open := strings.Index(key[1:], "[")
+			if open < 0 {
+				break
+			}
+
+			names = append(names, key[:open+1])
+			key = key[open+1:]
+		}
+
+		names = append(names, key)
+	}
+
+	return names
+}
+
+// errorTreeNode is a config item in the tree rendered by FormatErrors.
+type errorTreeNode struct {
+	name string
+	// messages holds the failures of the item itself, each starting with
+	// the separator that follows its name.
+	messages []string
+	children []*errorTreeNode
+}
+
+// add records message against the item reached by following names from n.
+func (n *errorTreeNode) add(names []string, message string) {
+	if len(names) == 0 {
+		n.messages = append(n.messages, message)
+
+		return
+	}
+
+	for _, child := range n.children {
+		if child.name == names[0] {
+			child.add(names[1:], message)
+
+			return
+		}
+	}
+
+	child := &errorTreeNode{name: names[0]}
+	n.children = append(n.children, child)
+	child.add(names[1:], message)
+}
+
+// write renders the children of n to b, each indented by indent. An item
+// with failures of its own and items beneath it lists its own failures
+// first, as list entries.
+func (n *errorTreeNode) write(b *strings.Builder, indent string) {
+	for _, child := range n.children {
+		if len(child.children) == 0 {
+			for _, message := range child.messages {
+				b.WriteString(indent + child.name + message + "\n")
+			}
+
+			continue
+		}
+
+		b.WriteString(indent + child.name + ":\n")
+
+		for _, message := range child.messages {
+			b.WriteString(indent + "  - " + strings.TrimPrefix(message, ": ") + "\n")
+		}
+
+		child.write(b, indent+"  ")
+	}
+}
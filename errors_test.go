@@ -0,0 +1,169 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigNested struct {
+	Server struct {
+		Address string `yaml:"address"`
+		Port    int    `yaml:"port"`
+	} `yaml:"server"`
+	Database struct {
+		User  string `yaml:"user"`
+		Level string `yaml:"level" yamlconfig:"oneof=low high"`
+	} `yaml:"database"`
+}
+
+type TestConfigPipeline struct {
+	Port    int           `yaml:"port"`
+	Timeout time.Duration `yaml:"timeout" yamlconfig:"default=soon"`
+	Name    string        `yaml:"name"`
+}
+
+type TestConfigVerbose struct {
+	Port     int    `yaml:"port" yamlconfig:"max=65535"`
+	Password string `yaml:"password" yamlconfig:"secret,oneof=a b"`
+	Name     string `yaml:"name" yamlconfig:"notblank"`
+}
+
+func TestErrors(t *testing.T) {
+	t.Run("Fail Fast Returns First Error", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		path := writeTempConfig(t, "server:\n  address: localhost\ndatabase:\n  level: medium\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg)
+
+		var validationErr *yamlconfig.ValidationError
+		require.True(t, errors.As(loadConfigErr, &validationErr))
+		require.Equal(t, "server.port", validationErr.Path)
+		require.Equal(t, "missing required config item", validationErr.Message)
+	})
+
+	t.Run("Collect Returns Every Error", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		path := writeTempConfig(t, "server:\n  address: localhost\ndatabase:\n  level: medium\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+
+		var multiErr *yamlconfig.MultiError
+		require.True(t, errors.As(loadConfigErr, &multiErr))
+		require.Len(t, multiErr.Errors, 3)
+		require.EqualError(t, multiErr, "server.port: missing required config item; "+
+			"database.user: missing required config item; "+
+			"database.level: value medium must be one of: low high")
+	})
+
+	t.Run("Collect Errors Unwrap", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		path := writeTempConfig(t, "server:\n  address: localhost\ndatabase:\n  user: app\n  level: medium\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+
+		var validationErr *yamlconfig.ValidationError
+		require.True(t, errors.As(loadConfigErr, &validationErr))
+		require.Equal(t, "server.port", validationErr.Path)
+
+		var multiErr *yamlconfig.MultiError
+		require.True(t, errors.As(loadConfigErr, &multiErr))
+		require.Len(t, multiErr.Unwrap(), 2)
+		require.ErrorIs(t, loadConfigErr, multiErr.Errors[1])
+	})
+
+	t.Run("Collect Without Errors", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		path := writeTempConfig(t, "server:\n  address: localhost\n  port: 80\ndatabase:\n  user: app\n  level: low\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect)))
+	})
+
+	t.Run("Verbose Errors Include Value", func(t *testing.T) {
+		cfg := TestConfigVerbose{}
+		path := writeTempConfig(t, "port: 70000\npassword: s3cret\nname: \" \"\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithVerboseErrors(), yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: port=70000: value 70000 exceeds max=65535; "+
+			"password=REDACTED: value REDACTED must be one of: a b; "+
+			"name=\" \": value must not be blank")
+	})
+
+	t.Run("Secret Values Masked Without Verbose", func(t *testing.T) {
+		cfg := TestConfigVerbose{}
+		path := writeTempConfig(t, "port: 1\npassword: s3cret\nname: app\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg)
+		require.EqualError(t, loadConfigErr, "failed to load the config: password: value REDACTED must be one of: a b")
+	})
+
+	t.Run("Collect Across Pipeline", func(t *testing.T) {
+		cfg := TestConfigPipeline{}
+		path := writeTempConfig(t, "port: eighty\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+
+		var multiErr *yamlconfig.MultiError
+		require.True(t, errors.As(loadConfigErr, &multiErr))
+		require.Len(t, multiErr.Errors, 4)
+		require.ErrorContains(t, multiErr.Errors[0], "line 1: cannot unmarshal !!str `eighty` into int")
+		require.ErrorContains(t, multiErr.Errors[1], "invalid default for timeout")
+		require.EqualError(t, multiErr.Errors[2], "timeout: missing required config item")
+		require.EqualError(t, multiErr.Errors[3], "name: missing required config item")
+	})
+
+	t.Run("Fail Fast Stops At Decode Error", func(t *testing.T) {
+		cfg := TestConfigPipeline{}
+		path := writeTempConfig(t, "port: eighty\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg)
+		require.ErrorContains(t, loadConfigErr, "failed to decode config file")
+
+		var multiErr *yamlconfig.MultiError
+		require.False(t, errors.As(loadConfigErr, &multiErr))
+	})
+
+	t.Run("Format Errors As Tree", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		path := writeTempConfig(t, "server:\n  address: localhost\ndatabase:\n  level: medium\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.Equal(t, "server:\n"+
+			"  port: missing required config item\n"+
+			"database:\n"+
+			"  user: missing required config item\n"+
+			"  level: value medium must be one of: low high\n", yamlconfig.FormatErrors(loadConfigErr))
+	})
+
+	t.Run("Format Errors Nested Paths", func(t *testing.T) {
+		err := &yamlconfig.MultiError{Errors: []error{
+			errors.New("line 4: cannot unmarshal !!str `x` into int"),
+			&yamlconfig.ValidationError{Path: "servers[0].tls.cert", Message: "missing required config item"},
+			&yamlconfig.ValidationError{Path: "servers[0].port", Message: "value 70000 exceeds max=65535", Value: "70000"},
+			&yamlconfig.ValidationError{Path: "servers[1]", Message: "is not unique"},
+			&yamlconfig.ValidationError{Path: "name", Message: "missing required config item"},
+			&yamlconfig.ValidationError{Path: "servers", Message: "has too many items"},
+		}}
+
+		require.Equal(t, "line 4: cannot unmarshal !!str `x` into int\n"+
+			"servers:\n"+
+			"  - has too many items\n"+
+			"  [0]:\n"+
+			"    tls:\n"+
+			"      cert: missing required config item\n"+
+			"    port=70000: value 70000 exceeds max=65535\n"+
+			"  [1]: is not unique\n"+
+			"name: missing required config item\n", yamlconfig.FormatErrors(err))
+	})
+
+	t.Run("Format Errors Single Error", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		path := writeTempConfig(t, "server:\n  address: localhost\n")
+
+		require.Equal(t, "server:\n  port: missing required config item\n", yamlconfig.FormatErrors(yamlconfig.LoadConfig(path, &cfg)))
+		require.Empty(t, yamlconfig.FormatErrors(nil))
+	})
+}
@@ -0,0 +1,108 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultEnvOpen and defaultEnvClose are the delimiters WithExpandEnv looks
+// for around the name of an environment variable.
+const (
+	defaultEnvOpen  = "${"
+	defaultEnvClose = "}"
+)
+
+// WithExpandEnv replaces references to environment variables written as
+// ${NAME} in the values of the document with the variable's value before
+// decoding. A fallback can be given as ${NAME:-default}, which is used when
+// the variable is unset or empty. An unset variable without a fallback
+// expands to nothing. Expanded values are read as though they were written
+// unquoted, so port: ${PORT} and port: "${PORT}" both decode into an int
+// field. Keys are not expanded.
+func WithExpandEnv() Option {
+	return func(o *options) {
+		o.expandEnv = true
+	}
+}
+
+// WithEnvDelimiters expands environment variables as WithExpandEnv does, but
+// looks for references between the given delimiters rather than ${ and }, for
+// files that are also processed by tools using that syntax. The fallback
+// syntax is unchanged, so WithEnvDelimiters("%{", "}") expands %{NAME} and
+// %{NAME:-default}. Values starting with a delimiter YAML reserves, such as
+// %, must be quoted.
+func WithEnvDelimiters(open, close string) Option {
+	return func(o *options) {
+		o.expandEnv = true
+		o.envOpen = open
+		o.envClose = close
+	}
+}
+
+// expandEnvNodes expands the environment variable references in every scalar
+// value beneath node, using the given delimiters. Scalars that change lose
+// their tag and quoting so the decoder resolves the expanded text again.
+func expandEnvNodes(node *yaml.Node, open, close string) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		expanded, err := expandEnvString(node.Value, open, close)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+
+		if expanded != node.Value {
+			node.Value = expanded
+			node.Tag = ""
+			node.Style = 0
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if err := expandEnvNodes(node.Content[i+1], open, close); err != nil {
+				return err
+			}
+		}
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := expandEnvNodes(child, open, close); err != nil {
+				return err
+			}
+		}
+	case yaml.AliasNode:
+	}
+
+	return nil
+}
+
+// expandEnvString replaces each reference to an environment variable in s,
+// written between the open and close delimiters, with its value.
+func expandEnvString(s, open, close string) (string, error) {
+	var out strings.Builder
+
+	for {
+		start := strings.Index(s, open)
+		if start < 0 {
+			out.WriteString(s)
+
+			return out.String(), nil
+		}
+
+		end := strings.Index(s[start+len(open):], close)
+		if end < 0 {
+			return "", fmt.Errorf("environment variable reference %q is missing its closing %q", s[start:], close)
+		}
+
+		name, fallback, hasFallback := strings.Cut(s[start+len(open):start+len(open)+end], ":-")
+
+		value := os.Getenv(name)
+		if value == "" && hasFallback {
+			value = fallback
+		}
+
+		out.WriteString(s[:start])
+		out.WriteString(value)
+		s = s[start+len(open)+end+len(close):]
+	}
+}
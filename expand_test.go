@@ -0,0 +1,68 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigExpand struct {
+	Host    string   `yaml:"host"`
+	Port    int      `yaml:"port"`
+	Region  string   `yaml:"region"`
+	Command string   `yaml:"command" yamlconfig:"omitempty"`
+	Tags    []string `yaml:"tags" yamlconfig:"omitempty"`
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("TEST_EXPAND_HOST", "db.local")
+	t.Setenv("TEST_EXPAND_PORT", "5432")
+	t.Setenv("TEST_EXPAND_EMPTY", "")
+
+	t.Run("Variables Expanded", func(t *testing.T) {
+		cfg := TestConfigExpand{}
+		path := writeTempConfig(t, "host: ${TEST_EXPAND_HOST}\nport: ${TEST_EXPAND_PORT}\n"+
+			"region: \"${TEST_EXPAND_UNSET:-eu-west-1}\"\ntags:\n  - ${TEST_EXPAND_EMPTY:-none}\n  - x${TEST_EXPAND_UNSET}y\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithExpandEnv()))
+		require.Equal(t, "db.local", cfg.Host)
+		require.Equal(t, 5432, cfg.Port)
+		require.Equal(t, "eu-west-1", cfg.Region)
+		require.Equal(t, []string{"none", "xy"}, cfg.Tags)
+	})
+
+	t.Run("Custom Delimiters", func(t *testing.T) {
+		cfg := TestConfigExpand{}
+		path := writeTempConfig(t, "host: \"%{TEST_EXPAND_HOST}\"\nport: '%{TEST_EXPAND_UNSET:-80}'\nregion: eu\ncommand: echo ${HOME}\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithEnvDelimiters("%{", "}")))
+		require.Equal(t, "db.local", cfg.Host)
+		require.Equal(t, 80, cfg.Port)
+		require.Equal(t, "echo ${HOME}", cfg.Command)
+	})
+
+	t.Run("Not Expanded Without Option", func(t *testing.T) {
+		cfg := TestConfigExpand{}
+		path := writeTempConfig(t, "host: ${TEST_EXPAND_HOST}\nport: 1\nregion: eu\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "${TEST_EXPAND_HOST}", cfg.Host)
+	})
+
+	t.Run("Unclosed Reference", func(t *testing.T) {
+		cfg := TestConfigExpand{}
+		path := writeTempConfig(t, "host: a\nport: 1\nregion: ${TEST_EXPAND_HOST\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithExpandEnv()),
+			"failed to decode config file: line 3: environment variable reference \"${TEST_EXPAND_HOST\" is missing its closing \"}\"")
+	})
+
+	t.Run("Empty Delimiters", func(t *testing.T) {
+		cfg := TestConfigExpand{}
+		path := writeTempConfig(t, "host: a\nport: 1\nregion: eu\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithEnvDelimiters("", "")),
+			"failed to decode config file: environment variable delimiters must not be empty")
+	})
+}
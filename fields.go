@@ -0,0 +1,178 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlKey returns the key yaml.v3 uses for the given struct field, along with
+// whether the field is inlined into its parent and whether it is skipped
+// entirely by the decoder.
+func yamlKey(field reflect.StructField) (key string, inline, skip bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false, true
+	}
+
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, flags, _ := strings.Cut(tag, ",")
+	for _, flag := range strings.Split(flags, ",") {
+		if flag == "inline" {
+			inline = true
+		}
+	}
+
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+
+	return name, inline, false
+}
+
+// fieldByKey finds the field of the struct val that is decoded from the given
+// YAML key, descending into inlined structs.
+func fieldByKey(val reflect.Value, key string) (reflect.Value, bool) {
+	for i := 0; i < val.NumField(); i++ {
+		name, inline, skip := yamlKey(val.Type().Field(i))
+		if skip {
+			continue
+		}
+
+		field := val.Field(i)
+		if inline && field.Kind() == reflect.Struct {
+			if found, ok := fieldByKey(field, key); ok {
+				return found, true
+			}
+
+			continue
+		}
+
+		if name == key {
+			return field, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// resolveNode follows document and alias nodes to the node holding the value.
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind { //nolint:exhaustive // Only wrapper nodes need resolving
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+
+			node = node.Content[0]
+		case yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the value node stored under key in the mapping node,
+// or nil if the node is not a mapping or the key is not present.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveNode(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveNode(node.Content[i+1])
+		}
+	}
+
+	return nil
+}
+
+// walkFields calls fn for every field of the struct val, or of each struct
+// value of the map val, that the YAML decoder reads, along with the field's
+// dotted path, then descends into nested structs, non-nil struct pointers and
+// the struct values of maps. Inlined structs share their parent's path and are
+// not passed to fn themselves, map values are given the map key as their path
+// element.
+func walkFields(val reflect.Value, path string, fn func(field reflect.Value, typ reflect.StructField, path string) error) error {
+	val = indirect(val)
+	if val.IsValid() && val.Kind() == reflect.Map {
+		return walkMapValues(val, func(key string, value reflect.Value) error {
+			return walkFields(value, joinPath(path, key), fn)
+		})
+	}
+
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		typ := val.Type().Field(i)
+
+		key, inline, skip := yamlKey(typ)
+		if skip {
+			continue
+		}
+
+		if inline {
+			if err := walkFields(field, path, fn); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		fieldPath := joinPath(path, key)
+		if err := fn(field, typ, fieldPath); err != nil {
+			return err
+		}
+
+		if err := walkFields(field, fieldPath, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// walkMapValues calls fn for each value of a map whose values are structs or
+// struct pointers, other than yaml.Node, in key order. Map values cannot be
+// changed in place, so fn is given a settable copy that is stored back into
+// the map afterwards.
+func walkMapValues(field reflect.Value, fn func(key string, value reflect.Value) error) error {
+	field = indirect(field)
+	if !field.IsValid() || field.Kind() != reflect.Map || derefType(field.Type().Elem()).Kind() != reflect.Struct || derefType(field.Type().Elem()) == nodeType {
+		return nil
+	}
+
+	keys := field.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	for _, key := range keys {
+		value := reflect.New(field.Type().Elem()).Elem()
+		value.Set(field.MapIndex(key))
+
+		err := fn(fmt.Sprint(key), value)
+		field.SetMapIndex(key, value)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
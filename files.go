@@ -0,0 +1,121 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ValidateFiles loads and validates each file independently against the
+// struct type of config, for example to check every environment's config
+// still matches the struct after a schema change. A fresh value of the
+// struct is used for every file, so config itself is left untouched.
+//
+// Parameters:
+//
+// paths: The paths of the configuration files to validate.
+// config: A pointer to a struct of the configuration type.
+// opts: Optional settings that change how the files are loaded.
+//
+// Returns:
+// []error: One entry per path, in the same order, holding nil for a valid
+// file or the error that file produced.
+//
+// Example:
+//
+// errs := yamlconfig.ValidateFiles([]string{"dev.yml", "prod.yml"}, &Config{})
+//
+//	for i, err := range errs {
+//	    if err != nil {
+//	        log.Printf("%s: %v", paths[i], err)
+//	    }
+//	}
+func ValidateFiles(paths []string, config interface{}, opts ...Option) []error {
+	errs := make([]error, len(paths))
+
+	for i, path := range paths {
+		errs[i] = validateFile(path, config, opts)
+	}
+
+	return errs
+}
+
+// ValidateAll loads and validates each file in the same way as ValidateFiles,
+// but spreads the files across a pool of at most concurrency goroutines, for
+// checks that cover hundreds of files. Every file is decoded into its own
+// fresh value of the struct type of prototype, so prototype is left
+// untouched. A logger, metrics sink or validator passed in opts is called
+// from several goroutines at once and must be safe for that.
+//
+// Parameters:
+//
+// paths: The paths of the configuration files to validate.
+// prototype: A pointer to a struct of the configuration type.
+// concurrency: The most files to load at once. Values below 1 load one file
+// at a time.
+// opts: Optional settings that change how the files are loaded.
+//
+// Returns:
+// map[string]error: One entry per path, holding nil for a valid file or the
+// error that file produced.
+//
+// Example:
+//
+//	errs := yamlconfig.ValidateAll(paths, &Config{}, runtime.NumCPU())
+//
+//	for path, err := range errs {
+//	    if err != nil {
+//	        log.Printf("%s: %v", path, err)
+//	    }
+//	}
+func ValidateAll(paths []string, prototype interface{}, concurrency int, opts ...Option) map[string]error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make(map[string]error, len(paths))
+		jobs = make(chan string)
+	)
+
+	for i := 0; i < concurrency && i < len(paths); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for path := range jobs {
+				err := validateFile(path, prototype, opts)
+
+				mu.Lock()
+				errs[path] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range paths {
+		jobs <- path
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
+
+// validateFile loads the file at path into a new value of config's type.
+func validateFile(path string, config interface{}, opts []Option) error {
+	typ := reflect.TypeOf(config)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a struct, please ensure the input is a struct pointer")
+	}
+
+	if loadErr := LoadConfig(path, reflect.New(typ.Elem()).Interface(), opts...); loadErr != nil {
+		return fmt.Errorf("%s: %w", path, loadErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package yamlconfig_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFiles(t *testing.T) {
+	t.Run("Validate Files Per File Results", func(t *testing.T) {
+		valid := writeTempConfig(t, "string: test\n")
+		invalid := writeTempConfig(t, "slice: [a]\n")
+
+		cfg := TestConfigOmitEmpty{}
+		errs := yamlconfig.ValidateFiles([]string{valid, invalid, "nonexistent.yml"}, &cfg)
+
+		require.Len(t, errs, 3)
+		require.NoError(t, errs[0])
+		require.ErrorContains(t, errs[1], invalid)
+		require.Error(t, errs[2])
+		require.Empty(t, cfg.String)
+	})
+
+	t.Run("Validate Files Requires Struct Pointer", func(t *testing.T) {
+		errs := yamlconfig.ValidateFiles([]string{writeTempConfig(t, "string: test\n")}, TestConfigOmitEmpty{})
+
+		require.Error(t, errs[0])
+	})
+}
+
+func TestValidateAll(t *testing.T) {
+	t.Run("Validate All Per File Results", func(t *testing.T) {
+		valid := writeTempConfig(t, "string: test\n")
+		invalid := writeTempConfig(t, "slice: [a]\n")
+
+		cfg := TestConfigOmitEmpty{}
+		errs := yamlconfig.ValidateAll([]string{valid, invalid, "nonexistent.yml"}, &cfg, 2)
+
+		require.Len(t, errs, 3)
+		require.NoError(t, errs[valid])
+		require.ErrorContains(t, errs[invalid], invalid)
+		require.Error(t, errs["nonexistent.yml"])
+		require.Empty(t, cfg.String)
+	})
+
+	t.Run("Validate All Many Files", func(t *testing.T) {
+		var paths []string
+		for i := 0; i < 50; i++ {
+			if i%10 == 0 {
+				paths = append(paths, writeTempConfig(t, "slice: [a]\n"))
+			} else {
+				paths = append(paths, writeTempConfig(t, fmt.Sprintf("string: tenant%d\n", i)))
+			}
+		}
+
+		for _, concurrency := range []int{0, 1, 8, 100} {
+			errs := yamlconfig.ValidateAll(paths, &TestConfigOmitEmpty{}, concurrency)
+
+			require.Len(t, errs, len(paths))
+
+			for i, path := range paths {
+				if i%10 == 0 {
+					require.Error(t, errs[path])
+				} else {
+					require.NoError(t, errs[path])
+				}
+			}
+		}
+	})
+
+	t.Run("Validate All No Files", func(t *testing.T) {
+		require.Empty(t, yamlconfig.ValidateAll(nil, &TestConfigOmitEmpty{}, 4))
+	})
+
+	t.Run("Validate All Requires Struct Pointer", func(t *testing.T) {
+		path := writeTempConfig(t, "string: test\n")
+		errs := yamlconfig.ValidateAll([]string{path}, TestConfigOmitEmpty{}, 4)
+
+		require.Error(t, errs[path])
+	})
+}
@@ -0,0 +1,56 @@
+package yamlconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fingerprint returns a stable hash of the configuration's effective values,
+// as a hex encoded SHA-256 of its canonical YAML form. Keys are written in
+// struct field order and map keys are sorted, so two configurations holding
+// the same values always have the same fingerprint, however their files were
+// written. Comparing fingerprints tells whether a reloaded configuration
+// actually differs. Pass WithRedactSecrets to leave the values of fields
+// tagged yamlconfig:"secret" out of the hash, including those of structs held
+// in maps and slices, so rotating a secret does not change the fingerprint.
+// Other write options do not affect the result.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+// opts: Optional settings, of which only WithRedactSecrets is used.
+//
+// Returns:
+// string: The fingerprint of the configuration.
+// error: An error if the configuration could not be encoded.
+//
+// Example:
+//
+// fingerprint, err := yamlconfig.Fingerprint(&cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func Fingerprint(config interface{}, opts ...WriteOption) (string, error) {
+	wo := newWriteOptions(opts)
+
+	var node yaml.Node
+	if encodeErr := node.Encode(config); encodeErr != nil {
+		return "", fmt.Errorf("failed to encode config: %w", encodeErr)
+	}
+
+	if wo.redactSecrets {
+		redactTagged(reflect.ValueOf(config), &node)
+	}
+
+	hash := sha256.New()
+	if writeErr := writeNode(hash, &node, newWriteOptions(nil)); writeErr != nil {
+		return "", writeErr
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,79 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigFingerprint struct {
+	Name     string            `yaml:"name"`
+	Password string            `yaml:"password" yamlconfig:"secret"`
+	Labels   map[string]string `yaml:"labels"`
+}
+
+type TestConfigFingerprintNested struct {
+	Name  string                            `yaml:"name"`
+	Users map[string][]TestConfigSecretUser `yaml:"users"`
+}
+
+func TestFingerprint(t *testing.T) {
+	load := func(t *testing.T, content string) TestConfigFingerprint {
+		t.Helper()
+
+		cfg := TestConfigFingerprint{}
+		require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, content), &cfg))
+
+		return cfg
+	}
+
+	t.Run("Stable Across Key Order", func(t *testing.T) {
+		a := load(t, "name: app\npassword: one\nlabels:\n  team: core\n  tier: web\n")
+		b := load(t, "labels: {tier: web, team: core}\npassword: \"one\"\nname: app\n")
+
+		fingerprintA, fingerprintErr := yamlconfig.Fingerprint(&a)
+		require.NoError(t, fingerprintErr)
+		require.Len(t, fingerprintA, 64)
+
+		fingerprintB, fingerprintErr := yamlconfig.Fingerprint(b, yamlconfig.WithIndent(2), yamlconfig.WithFlowSequences())
+		require.NoError(t, fingerprintErr)
+		require.Equal(t, fingerprintA, fingerprintB)
+	})
+
+	t.Run("Changes With Values", func(t *testing.T) {
+		a := load(t, "name: app\npassword: one\nlabels:\n  team: core\n")
+		b := load(t, "name: app\npassword: one\nlabels:\n  team: edge\n")
+
+		fingerprintA, _ := yamlconfig.Fingerprint(&a)
+		fingerprintB, _ := yamlconfig.Fingerprint(&b)
+		require.NotEqual(t, fingerprintA, fingerprintB)
+	})
+
+	t.Run("Secrets Excluded", func(t *testing.T) {
+		a := load(t, "name: app\npassword: one\nlabels:\n  team: core\n")
+		b := load(t, "name: app\npassword: two\nlabels:\n  team: core\n")
+
+		fingerprintA, _ := yamlconfig.Fingerprint(&a)
+		fingerprintB, _ := yamlconfig.Fingerprint(&b)
+		require.NotEqual(t, fingerprintA, fingerprintB)
+
+		fingerprintA, _ = yamlconfig.Fingerprint(&a, yamlconfig.WithRedactSecrets())
+		fingerprintB, _ = yamlconfig.Fingerprint(&b, yamlconfig.WithRedactSecrets())
+		require.Equal(t, fingerprintA, fingerprintB)
+		require.Equal(t, "one", a.Password)
+	})
+
+	t.Run("Nested Secrets Excluded", func(t *testing.T) {
+		a := TestConfigFingerprintNested{Name: "app", Users: map[string][]TestConfigSecretUser{"ops": {{Name: "ops", Password: "one"}}}}
+		b := TestConfigFingerprintNested{Name: "app", Users: map[string][]TestConfigSecretUser{"ops": {{Name: "ops", Password: "two"}}}}
+
+		fingerprintA, _ := yamlconfig.Fingerprint(&a, yamlconfig.WithRedactSecrets())
+		fingerprintB, _ := yamlconfig.Fingerprint(&b, yamlconfig.WithRedactSecrets())
+		require.Equal(t, fingerprintA, fingerprintB)
+
+		b.Users["ops"][0].Name = "admin"
+		fingerprintB, _ = yamlconfig.Fingerprint(&b, yamlconfig.WithRedactSecrets())
+		require.NotEqual(t, fingerprintA, fingerprintB)
+	})
+}
@@ -0,0 +1,138 @@
+package yamlconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// maxPort is the highest valid TCP or UDP port number.
+const maxPort = 65535
+
+// formats maps the names accepted by the yamlconfig:"format=name" rule to the
+// function checking a value has that format.
+var formats = map[string]func(field reflect.Value) error{
+	"ip":       validateIP,
+	"cidr":     validateCIDR,
+	"port":     validatePort,
+	"email":    validateEmail,
+	"duration": validateDurationFormat,
+	"json":     validateJSON,
+}
+
+// validateFormat checks that the field's value has the named format.
+func validateFormat(field reflect.Value, arg string) error {
+	check, ok := formats[arg]
+	if !ok {
+		return fmt.Errorf("unknown format %q", arg)
+	}
+
+	return check(field)
+}
+
+// validateIP checks that the string field holds an IPv4 or IPv6 address.
+func validateIP(field reflect.Value) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("format=ip is only supported on string fields")
+	}
+
+	if _, err := netip.ParseAddr(field.String()); err != nil {
+		return fmt.Errorf("value %q is not a valid IP address", field.String())
+	}
+
+	return nil
+}
+
+// validateCIDR checks that the string field holds an IP prefix in CIDR
+// notation, such as 10.0.0.0/8.
+func validateCIDR(field reflect.Value) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("format=cidr is only supported on string fields")
+	}
+
+	if _, err := netip.ParsePrefix(field.String()); err != nil {
+		return fmt.Errorf("value %q is not a valid CIDR", field.String())
+	}
+
+	return nil
+}
+
+// validatePort checks that the string or integer field holds a port number
+// between 1 and 65535.
+func validatePort(field reflect.Value) error {
+	var port int64
+
+	switch field.Kind() { //nolint:exhaustive // Ports are strings or integers
+	case reflect.String:
+		n, err := strconv.ParseInt(field.String(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("value %q is not a valid port", field.String())
+		}
+
+		port = n
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		port = field.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.Uint() > maxPort {
+			return fmt.Errorf("value %d is not a valid port, must be between 1 and %d", field.Uint(), maxPort)
+		}
+
+		port = int64(field.Uint())
+	default:
+		return fmt.Errorf("format=port is only supported on string and integer fields")
+	}
+
+	if port < 1 || port > maxPort {
+		return fmt.Errorf("value %v is not a valid port, must be between 1 and %d", field, maxPort)
+	}
+
+	return nil
+}
+
+// validateEmail checks that the string field holds a bare email address, such
+// as ops@example.com, without a display name.
+func validateEmail(field reflect.Value) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("format=email is only supported on string fields")
+	}
+
+	addr, err := mail.ParseAddress(field.String())
+	if err != nil || addr.Address != field.String() {
+		return fmt.Errorf("value %q is not a valid email address", field.String())
+	}
+
+	return nil
+}
+
+// validateDurationFormat checks that the string field holds a duration that
+// time.ParseDuration accepts, such as 1m30s, for fields kept as strings.
+func validateDurationFormat(field reflect.Value) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("format=duration is only supported on string fields")
+	}
+
+	if _, err := time.ParseDuration(field.String()); err != nil {
+		return fmt.Errorf("value %q is not a valid duration: %w", field.String(), err)
+	}
+
+	return nil
+}
+
+// validateJSON checks that the string field holds well-formed JSON, such as an
+// embedded policy document. The value is left out of the error as it may be
+// long.
+func validateJSON(field reflect.Value) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("format=json is only supported on string fields")
+	}
+
+	if !json.Valid([]byte(field.String())) {
+		return fmt.Errorf("value is not valid JSON")
+	}
+
+	return nil
+}
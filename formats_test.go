@@ -0,0 +1,58 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigNetwork struct {
+	Address    string `yaml:"address" yamlconfig:"omitempty,format=ip"`
+	Subnet     string `yaml:"subnet" yamlconfig:"omitempty,format=cidr"`
+	Port       int    `yaml:"port" yamlconfig:"omitempty,format=port"`
+	PortString string `yaml:"port_string" yamlconfig:"omitempty,format=port"`
+	Email      string `yaml:"email" yamlconfig:"omitempty,format=email"`
+	Timeout    string `yaml:"timeout" yamlconfig:"omitempty,format=duration"`
+	Policy     string `yaml:"policy" yamlconfig:"omitempty,format=json"`
+}
+
+func TestFormats(t *testing.T) {
+	t.Run("Valid Network Formats", func(t *testing.T) {
+		cfg := TestConfigNetwork{}
+		path := writeTempConfig(t, "address: ::1\nsubnet: 10.0.0.0/8\nport: 65535\nport_string: \"8080\"\nemail: ops@example.com\ntimeout: 1m30s\npolicy: '{\"Version\": \"2012-10-17\", \"Statement\": []}'\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Invalid Network Formats", func(t *testing.T) {
+		tests := map[string]string{
+			"address: 10.0.0.256\n":          "address: value \"10.0.0.256\" is not a valid IP address",
+			"subnet: 10.0.0.0\n":             "subnet: value \"10.0.0.0\" is not a valid CIDR",
+			"port: 70000\n":                  "port: value 70000 is not a valid port, must be between 1 and 65535",
+			"email: Ops <ops@example.com>\n": "email: value \"Ops <ops@example.com>\" is not a valid email address",
+			"port: -1\n":                     "port: value -1 is not a valid port",
+			"port_string: http\n":            "port_string: value \"http\" is not a valid port",
+			"port_string: \"0\"\n":           "port_string: value 0 is not a valid port",
+			"timeout: 90\n":                  "timeout: value \"90\" is not a valid duration: time: missing unit in duration \"90\"",
+			"timeout: 5 minutes\n":           "timeout: value \"5 minutes\" is not a valid duration: time: unknown unit",
+			"policy: '{\"Version\": }'\n":    "policy: value is not valid JSON",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigNetwork{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+		}
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		cfg := struct {
+			Value string `yaml:"value" yamlconfig:"format=unknown"`
+		}{}
+		path := writeTempConfig(t, "value: x\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "unknown format \"unknown\"")
+	})
+}
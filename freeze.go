@@ -0,0 +1,113 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// frozen holds a deep copy of each configuration loaded with WithFreeze,
+// keyed by the pointer the configuration was loaded into.
+var frozen = struct {
+	sync.Mutex
+	m map[interface{}]reflect.Value
+}{m: map[interface{}]reflect.Value{}}
+
+// WithFreeze records a copy of the configuration once it has loaded and
+// validated, so VerifyUnchanged can later report whether code has modified
+// it. It is a debugging aid for programs that should treat their
+// configuration as read-only. The copy is kept for the life of the program.
+func WithFreeze() Option {
+	return func(o *options) {
+		o.freeze = true
+	}
+}
+
+// freeze records a deep copy of the configuration config points to.
+func freeze(config interface{}) {
+	val := reflect.ValueOf(config)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return
+	}
+
+	snapshot := reflect.New(val.Elem().Type()).Elem()
+	deepCopy(snapshot, val.Elem())
+
+	frozen.Lock()
+	defer frozen.Unlock()
+
+	frozen.m[config] = snapshot
+}
+
+// VerifyUnchanged checks that a configuration loaded with WithFreeze still
+// holds the values it was loaded with.
+//
+// Parameters:
+//
+// config: The pointer the configuration was loaded into.
+//
+// Returns:
+// error: An error listing the dotted paths of every changed config item, or
+// nil if nothing has changed.
+//
+// Example:
+//
+//	if err := yamlconfig.VerifyUnchanged(&cfg); err != nil {
+//	    log.Printf("config modified at runtime: %v", err)
+//	}
+func VerifyUnchanged(config interface{}) error {
+	val := reflect.ValueOf(config)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("expected a pointer to a struct, please ensure the input is a struct pointer")
+	}
+
+	frozen.Lock()
+	snapshot, ok := frozen.m[config]
+	frozen.Unlock()
+
+	if !ok {
+		return fmt.Errorf("config was not loaded with WithFreeze")
+	}
+
+	var changed []string
+
+	collectChanges(snapshot, val.Elem(), "", &changed)
+
+	if len(changed) > 0 {
+		return fmt.Errorf("config changed after load: %s", strings.Join(changed, ", "))
+	}
+
+	return nil
+}
+
+// collectChanges appends the dotted path of every config item that differs
+// between the loaded value and the current value. Structs are compared field
+// by field, anything else as a whole.
+func collectChanges(loaded, current reflect.Value, path string, changed *[]string) {
+	if loaded.Kind() == reflect.Ptr && !loaded.IsNil() && !current.IsNil() && loaded.Elem().Kind() == reflect.Struct {
+		loaded, current = loaded.Elem(), current.Elem()
+	}
+
+	if loaded.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(loaded.Interface(), current.Interface()) {
+			*changed = append(*changed, path)
+		}
+
+		return
+	}
+
+	for i := 0; i < loaded.NumField(); i++ {
+		key, inline, skip := yamlKey(loaded.Type().Field(i))
+		if skip || !loaded.Field(i).CanInterface() {
+			continue
+		}
+
+		fieldPath := path
+		if !inline {
+			fieldPath = joinPath(path, key)
+		}
+
+		collectChanges(loaded.Field(i), current.Field(i), fieldPath, changed)
+	}
+}
@@ -0,0 +1,41 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	content := "string: test\nint: 1\nbool: true\nslice: [a]\nunit: 1\nfloat: 1.5\nstruct:\n  string: nested\n"
+
+	t.Run("Unchanged Config", func(t *testing.T) {
+		cfg := TestConfigStruct{}
+		path := writeTempConfig(t, content)
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithFreeze()))
+		require.NoError(t, yamlconfig.VerifyUnchanged(&cfg))
+	})
+
+	t.Run("Changed Config", func(t *testing.T) {
+		cfg := TestConfigStruct{}
+		path := writeTempConfig(t, content)
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithFreeze()))
+
+		cfg.Int = 2
+		cfg.Slice[0] = "b"
+		cfg.Struct.String = "changed"
+
+		require.EqualError(t, yamlconfig.VerifyUnchanged(&cfg), "config changed after load: int, slice, struct.string")
+	})
+
+	t.Run("Not Frozen", func(t *testing.T) {
+		cfg := TestConfigStruct{}
+		path := writeTempConfig(t, content)
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.EqualError(t, yamlconfig.VerifyUnchanged(&cfg), "config was not loaded with WithFreeze")
+	})
+}
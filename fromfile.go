@@ -0,0 +1,93 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileKeySuffix is appended to the YAML key of a fromfile field to name the
+// sibling key holding the path of the file to read the value from.
+const fileKeySuffix = "_file"
+
+// applyFromFile populates string fields tagged with yamlconfig:"fromfile"
+// from the file named by their sibling "<key>_file" key in the document. The
+// file contents are trimmed of surrounding whitespace. It is an error to set
+// both the value and the file for the same field. The path of each file read
+// is passed to opened, and fields that cannot be populated are reported to
+// errs.
+func applyFromFile(val reflect.Value, node *yaml.Node, opened func(path string), errs *errorList) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	node = resolveNode(node)
+	if val.Kind() != reflect.Struct || node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		typ := val.Type().Field(i)
+
+		key, inline, skip := yamlKey(typ)
+		if skip {
+			continue
+		}
+
+		if inline {
+			if err := applyFromFile(field, node, opened, errs); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if parseTag(typ.Tag.Get("yamlconfig")).has("fromfile") {
+			if err := errs.add(readFromFile(field, typ, node, key, opened)); err != nil {
+				return err
+			}
+		}
+
+		if err := applyFromFile(field, mappingValue(node, key), opened, errs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readFromFile sets a single fromfile field from the file named by the
+// "<key>_file" entry of the mapping node, if present.
+func readFromFile(field reflect.Value, typ reflect.StructField, node *yaml.Node, key string, opened func(path string)) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("fromfile is only supported on string fields: %s", typ.Name)
+	}
+
+	fileNode := mappingValue(node, key+fileKeySuffix)
+	if fileNode == nil || fileNode.Value == "" {
+		return nil
+	}
+
+	if !isEmpty(field) {
+		return fmt.Errorf("only one of %s and %s may be set", key, key+fileKeySuffix)
+	}
+
+	contents, readErr := os.ReadFile(fileNode.Value)
+	if readErr != nil {
+		return fmt.Errorf("failed to read %s: %w", key+fileKeySuffix, readErr)
+	}
+
+	opened(fileNode.Value)
+
+	field.SetString(strings.TrimSpace(string(contents)))
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigFromFile struct {
+	Database struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password" yamlconfig:"fromfile"`
+	} `yaml:"database"`
+}
+
+func TestFromFile(t *testing.T) {
+	t.Run("From File Reads Value", func(t *testing.T) {
+		cfg := TestConfigFromFile{}
+		secret := writeTempConfig(t, "s3cret\n")
+		path := writeTempConfig(t, "database:\n  user: app\n  password_file: "+secret+"\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "s3cret", cfg.Database.Password)
+	})
+
+	t.Run("From File Inline Value", func(t *testing.T) {
+		cfg := TestConfigFromFile{}
+		path := writeTempConfig(t, "database:\n  user: app\n  password: inline\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "inline", cfg.Database.Password)
+	})
+
+	t.Run("From File Both Set", func(t *testing.T) {
+		cfg := TestConfigFromFile{}
+		secret := writeTempConfig(t, "s3cret\n")
+		path := writeTempConfig(t, "database:\n  user: app\n  password: inline\n  password_file: "+secret+"\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "only one of password and password_file")
+	})
+
+	t.Run("From File Neither Set", func(t *testing.T) {
+		cfg := TestConfigFromFile{}
+		path := writeTempConfig(t, "database:\n  user: app\n")
+
+		require.Error(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("From File Missing File", func(t *testing.T) {
+		cfg := TestConfigFromFile{}
+		path := writeTempConfig(t, "database:\n  user: app\n  password_file: /nonexistent/secret\n")
+
+		require.Error(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+}
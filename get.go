@@ -0,0 +1,142 @@
+package yamlconfig
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is a single step of a config path: either a key into a struct
+// or map, or an index into a slice or array.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath splits a dotted config path such as "servers[0].name" into its
+// segments. It reports false if the path is malformed.
+func parsePath(path string) ([]pathSegment, bool) {
+	var segments []pathSegment
+
+	for _, part := range strings.Split(path, ".") {
+		key, rest, hasIndex := strings.Cut(part, "[")
+		if key == "" {
+			return nil, false
+		}
+
+		segments = append(segments, pathSegment{key: key})
+
+		if !hasIndex {
+			continue
+		}
+
+		// Consume each bracketed index following the key
+		for rest = "[" + rest; rest != ""; {
+			end := strings.Index(rest, "]")
+			if !strings.HasPrefix(rest, "[") || end < 0 {
+				return nil, false
+			}
+
+			index, atoiErr := strconv.Atoi(rest[1:end])
+			if atoiErr != nil || index < 0 {
+				return nil, false
+			}
+
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		}
+	}
+
+	return segments, true
+}
+
+// Get looks up a value in a configuration by path, without needing to know the
+// struct type at compile time. The path is made of YAML key names separated by
+// dots, with bracketed indices for slices and arrays, for example
+// "servers[0].address". Map entries are addressed by their key.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+// path: The dotted path of the value to look up.
+//
+// Returns:
+// interface{}: The value found at the path.
+// bool: Whether a value was found at the path.
+//
+// Example:
+//
+// port, ok := yamlconfig.Get(&cfg, "server.port")
+func Get(config interface{}, path string) (interface{}, bool) {
+	segments, ok := parsePath(path)
+	if !ok {
+		return nil, false
+	}
+
+	val, found := lookupPath(reflect.ValueOf(config), segments)
+	if !found || !val.CanInterface() {
+		return nil, false
+	}
+
+	return val.Interface(), true
+}
+
+// lookupPath walks val following the segments and returns the value reached.
+func lookupPath(val reflect.Value, segments []pathSegment) (reflect.Value, bool) {
+	for _, segment := range segments {
+		val = indirect(val)
+		if !val.IsValid() {
+			return reflect.Value{}, false
+		}
+
+		var found bool
+		if val, found = step(val, segment); !found {
+			return reflect.Value{}, false
+		}
+	}
+
+	return val, true
+}
+
+// step follows a single path segment from val.
+func step(val reflect.Value, segment pathSegment) (reflect.Value, bool) {
+	switch val.Kind() { //nolint:exhaustive // Only containers can be stepped into
+	case reflect.Struct:
+		if segment.isIndex {
+			return reflect.Value{}, false
+		}
+
+		return fieldByKey(val, segment.key)
+	case reflect.Map:
+		if segment.isIndex || val.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+
+		entry := val.MapIndex(reflect.ValueOf(segment.key).Convert(val.Type().Key()))
+
+		return entry, entry.IsValid()
+	case reflect.Slice, reflect.Array:
+		if !segment.isIndex || segment.index >= val.Len() {
+			return reflect.Value{}, false
+		}
+
+		return val.Index(segment.index), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// indirect dereferences pointers and interfaces until it reaches a concrete
+// value. It returns the zero Value if it meets a nil pointer or interface.
+func indirect(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}
+		}
+
+		val = val.Elem()
+	}
+
+	return val
+}
@@ -0,0 +1,49 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigGet struct {
+	Name    string `yaml:"name"`
+	Servers []struct {
+		Address string `yaml:"address"`
+		Ports   []int  `yaml:"ports"`
+	} `yaml:"servers"`
+	Labels   map[string]string `yaml:"labels"`
+	Database *struct {
+		User string `yaml:"user"`
+	} `yaml:"database"`
+}
+
+func TestGet(t *testing.T) {
+	cfg := TestConfigGet{}
+	path := writeTempConfig(t, "name: app\nservers:\n  - address: a\n    ports: [80, 443]\nlabels:\n  region: eu\ndatabase:\n  user: root\n")
+	require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+	t.Run("Get Values By Path", func(t *testing.T) {
+		tests := map[string]interface{}{
+			"name":                "app",
+			"servers[0].address":  "a",
+			"servers[0].ports[1]": 443,
+			"labels.region":       "eu",
+			"database.user":       "root",
+		}
+
+		for path, expected := range tests {
+			value, ok := yamlconfig.Get(&cfg, path)
+			require.True(t, ok, path)
+			require.Equal(t, expected, value, path)
+		}
+	})
+
+	t.Run("Get Missing Paths", func(t *testing.T) {
+		for _, path := range []string{"missing", "servers[1]", "labels.zone", "name[0]", "servers[x]", "servers.[0]", ""} {
+			_, ok := yamlconfig.Get(&cfg, path)
+			require.False(t, ok, path)
+		}
+	})
+}
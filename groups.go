@@ -0,0 +1,157 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// groupMember is a field belonging to a named group of fields that are
+// checked together, such as the fields tagged yamlconfig:"exactly=2:auth".
+type groupMember struct {
+	// key is the field's YAML key.
+	key string
+	// count is the number of members the field's tag requires to be set, for
+	// rules that take one.
+	count string
+	// set reports whether the field holds a value.
+	set bool
+}
+
+// groupRules lists the rules that check a group of fields together. Each
+// splits the argument of its tag option into the count it takes, if any, and
+// the name of the group, and checks the members of each group it forms.
+var groupRules = []struct {
+	option string
+	split  func(arg string) (count, name string)
+	check  func(name string, members []groupMember) error
+}{
+	{"exactly", splitCount, checkExactly},
+	{"allornone", func(arg string) (string, string) { return "", arg }, checkAllOrNone},
+}
+
+// splitCount splits a group argument given as count:group.
+func splitCount(arg string) (count, name string) {
+	count, name, _ = strings.Cut(arg, ":")
+
+	return count, name
+}
+
+// validateGroups checks the groups formed by the fields of the struct val
+// tagged with a group rule, such as yamlconfig:"exactly=n:group", rule by
+// rule in the order each group is first named. A group only holds fields of
+// the same struct, and isSet decides which members are set. Each failing
+// group is passed to fail with the key of its first member, and validation
+// stops if fail returns an error.
+func validateGroups(val reflect.Value, isSet func(field reflect.Value, key string) bool, fail func(key string, err error) error) error {
+	for _, rule := range groupRules {
+		var names []string
+
+		groups := map[string][]groupMember{}
+
+		collectGroupMembers(val, rule.option, rule.split, isSet, func(name string, member groupMember) {
+			if _, ok := groups[name]; !ok {
+				names = append(names, name)
+			}
+
+			groups[name] = append(groups[name], member)
+		})
+
+		for _, name := range names {
+			members := groups[name]
+			if err := rule.check(name, members); err != nil {
+				if failErr := fail(members[0].key, err); failErr != nil {
+					return failErr
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectGroupMembers calls add with the group named by the given option of
+// each field of the struct val. Groups do not span inlined structs, whose
+// groups are checked when the inlined struct is validated.
+func collectGroupMembers(val reflect.Value, option string, split func(arg string) (string, string), isSet func(field reflect.Value, key string) bool,
+	add func(name string, member groupMember),
+) {
+	for i := 0; i < val.NumField(); i++ {
+		typ := val.Type().Field(i)
+
+		key, inline, skip := yamlKey(typ)
+		if skip || inline {
+			continue
+		}
+
+		arg, ok := parseTag(typ.Tag.Get("yamlconfig")).get(option)
+		if !ok {
+			continue
+		}
+
+		count, name := split(arg)
+		add(name, groupMember{key: key, count: count, set: isSet(val.Field(i), key)})
+	}
+}
+
+// checkExactly checks that the number of members of the named group that are
+// set is the count given in their tags.
+func checkExactly(name string, members []groupMember) error {
+	n, atoiErr := strconv.Atoi(members[0].count)
+	if atoiErr != nil || n < 0 || name == "" {
+		return fmt.Errorf("exactly must be given as count:group, such as exactly=2:auth, got exactly=%s:%s", members[0].count, name)
+	}
+
+	keys := make([]string, len(members))
+
+	var set []string
+
+	for i, member := range members {
+		if member.count != members[0].count {
+			return fmt.Errorf("exactly=%s:%s does not match exactly=%s:%s on %s", members[0].count, name, member.count, name, member.key)
+		}
+
+		keys[i] = member.key
+		if member.set {
+			set = append(set, member.key)
+		}
+	}
+
+	if len(set) == n {
+		return nil
+	}
+
+	found := "none"
+	if len(set) > 0 {
+		found = fmt.Sprintf("%d: %s", len(set), strings.Join(set, ", "))
+	}
+
+	return fmt.Errorf("exactly %d of %s must be set, found %s", n, strings.Join(keys, ", "), found)
+}
+
+// checkAllOrNone checks that the members of the named group are either all
+// set or all empty, listing the members that are missing when only some are
+// set.
+func checkAllOrNone(name string, members []groupMember) error {
+	if name == "" {
+		return fmt.Errorf("allornone must name a group, such as allornone=tls")
+	}
+
+	keys := make([]string, len(members))
+
+	var missing []string
+
+	for i, member := range members {
+		keys[i] = member.key
+		if !member.set {
+			missing = append(missing, member.key)
+		}
+	}
+
+	if len(missing) == 0 || len(missing) == len(members) {
+		return nil
+	}
+
+	return fmt.Errorf("all or none of %s must be set, missing: %s", strings.Join(keys, ", "), strings.Join(missing, ", "))
+}
@@ -0,0 +1,132 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigExactly struct {
+	Name string `yaml:"name"`
+	Auth struct {
+		Token    string `yaml:"token" yamlconfig:"omitempty,exactly=2:auth"`
+		User     string `yaml:"user" yamlconfig:"omitempty,exactly=2:auth"`
+		Password string `yaml:"password" yamlconfig:"omitempty,exactly=2:auth"`
+		Cert     string `yaml:"cert" yamlconfig:"omitempty,exactly=2:auth"`
+		Region   string `yaml:"region" yamlconfig:"omitempty,exactly=1:location"`
+		Zone     string `yaml:"zone" yamlconfig:"omitempty,exactly=1:location"`
+	} `yaml:"auth"`
+}
+
+type TestConfigAllOrNone struct {
+	Name string `yaml:"name"`
+	TLS  struct {
+		Cert string `yaml:"cert" yamlconfig:"omitempty,allornone=tls"`
+		Key  string `yaml:"key" yamlconfig:"omitempty,allornone=tls"`
+		CA   string `yaml:"ca" yamlconfig:"omitempty,allornone=tls"`
+	} `yaml:"tls" yamlconfig:"omitempty"`
+	Proxy struct {
+		Host string `yaml:"host" yamlconfig:"omitempty,allornone=proxy"`
+		Port int    `yaml:"port" yamlconfig:"omitempty,allornone=proxy"`
+	} `yaml:"proxy" yamlconfig:"omitempty"`
+}
+
+type TestConfigZeroGroups struct {
+	Shard  int    `yaml:"shard" yamlconfig:"omitempty,exactly=1:placement"`
+	Region string `yaml:"region" yamlconfig:"omitempty,exactly=1:placement"`
+	Host   string `yaml:"host" yamlconfig:"omitempty,allornone=proxy"`
+	Port   int    `yaml:"port" yamlconfig:"omitempty,allornone=proxy"`
+}
+
+func TestGroups(t *testing.T) {
+	t.Run("Exactly Satisfied", func(t *testing.T) {
+		cfg := TestConfigExactly{}
+		path := writeTempConfig(t, "name: app\nauth:\n  user: admin\n  password: secret\n  zone: a\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Exactly Violated", func(t *testing.T) {
+		tests := map[string]string{
+			"name: app\nauth:\n  token: t\n  user: u\n  password: p\n  zone: a\n": "auth.token: exactly 2 of token, user, password, cert must be set, found 3: token, user, password",
+			"name: app\nauth:\n  cert: c\n  region: r\n":                          "auth.token: exactly 2 of token, user, password, cert must be set, found 1: cert",
+			"name: app\nauth:\n  token: t\n  cert: c\n  region: r\n  zone: a\n":   "auth.region: exactly 1 of region, zone must be set, found 2: region, zone",
+			"name: app\nauth:\n  token: \"\"\n  cert: c\n  region: r\n":           "auth.token: exactly 2 of token, user, password, cert must be set, found 1: cert",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigExactly{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: "+expected)
+		}
+	})
+
+	t.Run("Exactly None Set", func(t *testing.T) {
+		cfg := TestConfigExactly{}
+		path := writeTempConfig(t, "name: app\nauth:\n  region: r\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect)),
+			"failed to load the config: auth.token: exactly 2 of token, user, password, cert must be set, found none")
+	})
+
+	t.Run("Exactly Invalid Tags", func(t *testing.T) {
+		cfg := struct {
+			A string `yaml:"a" yamlconfig:"omitempty,exactly=two:pair"`
+			B string `yaml:"b" yamlconfig:"omitempty,exactly=two:pair"`
+		}{}
+		require.EqualError(t, yamlconfig.LoadConfig(writeTempConfig(t, "a: x\n"), &cfg),
+			"failed to load the config: a: exactly must be given as count:group, such as exactly=2:auth, got exactly=two:pair")
+
+		mismatched := struct {
+			A string `yaml:"a" yamlconfig:"omitempty,exactly=1:pair"`
+			B string `yaml:"b" yamlconfig:"omitempty,exactly=2:pair"`
+		}{}
+		require.EqualError(t, yamlconfig.LoadConfig(writeTempConfig(t, "a: x\n"), &mismatched),
+			"failed to load the config: a: exactly=1:pair does not match exactly=2:pair on b")
+	})
+
+	t.Run("All Or None Satisfied", func(t *testing.T) {
+		for _, content := range []string{
+			"name: app\n",
+			"name: app\ntls:\n  cert: c.pem\n  key: k.pem\n  ca: ca.pem\n",
+			"name: app\ntls:\n  cert: c.pem\n  key: k.pem\n  ca: ca.pem\nproxy:\n  host: p\n  port: 3128\n",
+		} {
+			cfg := TestConfigAllOrNone{}
+			path := writeTempConfig(t, content)
+
+			require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		}
+	})
+
+	t.Run("All Or None Partially Set", func(t *testing.T) {
+		cfg := TestConfigAllOrNone{}
+		path := writeTempConfig(t, "name: app\ntls:\n  cert: c.pem\nproxy:\n  port: 3128\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect)),
+			"failed to load the config: tls.cert: all or none of cert, key, ca must be set, missing: key, ca; "+
+				"proxy.host: all or none of host, port must be set, missing: host")
+	})
+
+	t.Run("Groups With Zero Numbers", func(t *testing.T) {
+		for _, content := range []string{"region: eu\n", "shard: 0\nhost: p\nport: 0\n"} {
+			cfg := TestConfigZeroGroups{}
+			require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, content), &cfg, yamlconfig.WithAllowZeroNumbers()))
+		}
+
+		cfg := TestConfigZeroGroups{}
+		require.EqualError(t, yamlconfig.LoadConfig(writeTempConfig(t, "shard: 0\nregion: eu\nhost: p\n"), &cfg,
+			yamlconfig.WithAllowZeroNumbers(), yamlconfig.WithErrorMode(yamlconfig.Collect)),
+			"failed to load the config: shard: exactly 1 of shard, region must be set, found 2: shard, region; "+
+				"host: all or none of host, port must be set, missing: port")
+	})
+
+	t.Run("All Or None Invalid Tag", func(t *testing.T) {
+		cfg := struct {
+			A string `yaml:"a" yamlconfig:"omitempty,allornone="`
+		}{}
+		require.EqualError(t, yamlconfig.LoadConfig(writeTempConfig(t, "a: x\n"), &cfg),
+			"failed to load the config: a: allornone must name a group, such as allornone=tls")
+	})
+}
@@ -0,0 +1,119 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AfterDecoder is implemented by configuration types that post-process
+// themselves once decoded, for example to compute derived fields or normalise
+// values. AfterDecode is called after defaults are applied and before
+// validation. Returning an error stops loading.
+type AfterDecoder interface {
+	AfterDecode() error
+}
+
+// WithValidator registers a function that validates the configuration as a
+// whole, for checks that span many fields such as comparing a config_hash
+// field with a hash of the rest of the configuration. The function is called
+// with the pointer passed to the loader, after every field has passed its
+// required, rule and relation checks. In Collect mode it is called even when
+// field checks failed and its error is collected with theirs. Validators run
+// in the order they were registered.
+//
+// Example:
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithValidator(func(config interface{}) error {
+//	    c := config.(*Config)
+//	    if c.ConfigHash != hashOf(c) {
+//	        return errors.New("config_hash does not match the config")
+//	    }
+//	    return nil
+//	}))
+func WithValidator(validate func(config interface{}) error) Option {
+	return func(o *options) {
+		if validate != nil {
+			o.configValidators = append(o.configValidators, validate)
+		}
+	}
+}
+
+// afterDecoderType is the reflect.Type of the AfterDecoder interface.
+var afterDecoderType = reflect.TypeOf((*AfterDecoder)(nil)).Elem()
+
+// applyAfterDecode calls AfterDecode on every value within val that implements
+// AfterDecoder. The tree is walked depth first and nested values are called
+// before the struct holding them, so a parent's hook sees its children already
+// processed. Struct fields are visited in declaration order, slice elements in
+// index order and map values in key order.
+func applyAfterDecode(val reflect.Value, path string) error {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	switch val.Kind() { //nolint:exhaustive // Only containers need descending into
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			key, inline, skip := yamlKey(val.Type().Field(i))
+			if skip {
+				continue
+			}
+
+			fieldPath := path
+			if !inline {
+				fieldPath = joinPath(path, key)
+			}
+
+			if err := applyAfterDecode(val.Field(i), fieldPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			if err := applyAfterDecode(val.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if err := walkMapValues(val, func(key string, value reflect.Value) error {
+			return applyAfterDecode(value, joinPath(path, key))
+		}); err != nil {
+			return err
+		}
+	}
+
+	return callAfterDecode(val, path)
+}
+
+// callAfterDecode calls AfterDecode on val if it, or a pointer to it,
+// implements AfterDecoder.
+func callAfterDecode(val reflect.Value, path string) error {
+	if val.Kind() != reflect.Struct || !val.CanInterface() {
+		return nil
+	}
+
+	var hook AfterDecoder
+
+	switch {
+	case val.CanAddr() && val.Addr().Type().Implements(afterDecoderType):
+		hook, _ = val.Addr().Interface().(AfterDecoder)
+	case val.Type().Implements(afterDecoderType):
+		hook, _ = val.Interface().(AfterDecoder)
+	default:
+		return nil
+	}
+
+	if err := hook.AfterDecode(); err != nil {
+		if path == "" {
+			return err
+		}
+
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestHookUpstream struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	Addr string `yaml:"-"`
+}
+
+func (u *TestHookUpstream) AfterDecode() error {
+	if u.Port < 0 {
+		return errors.New("port must not be negative")
+	}
+
+	u.Addr = fmt.Sprintf("%s:%d", u.Host, u.Port)
+
+	return nil
+}
+
+type TestConfigHooks struct {
+	Name      string                      `yaml:"name"`
+	Primary   TestHookUpstream            `yaml:"primary"`
+	Replicas  []TestHookUpstream          `yaml:"replicas" yamlconfig:"omitempty"`
+	Upstreams map[string]TestHookUpstream `yaml:"upstreams" yamlconfig:"omitempty"`
+	Summary   string                      `yaml:"-"`
+}
+
+func (c *TestConfigHooks) AfterDecode() error {
+	c.Name = strings.ToLower(c.Name)
+
+	addrs := []string{c.Primary.Addr}
+	for _, replica := range c.Replicas {
+		addrs = append(addrs, replica.Addr)
+	}
+
+	c.Summary = strings.Join(addrs, ",")
+
+	return nil
+}
+
+func TestHooks(t *testing.T) {
+	t.Run("After Decode Computes Derived Fields", func(t *testing.T) {
+		cfg := TestConfigHooks{}
+		path := writeTempConfig(t, "name: APP\nprimary:\n  host: a\n  port: 1\nreplicas:\n  - host: b\n    port: 2\nupstreams:\n  c:\n    host: c\n    port: 3\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "app", cfg.Name)
+		require.Equal(t, "a:1", cfg.Primary.Addr)
+		require.Equal(t, "c:3", cfg.Upstreams["c"].Addr)
+		require.Equal(t, "a:1,b:2", cfg.Summary)
+	})
+
+	t.Run("After Decode Error Aborts Load", func(t *testing.T) {
+		cfg := TestConfigHooks{}
+		path := writeTempConfig(t, "name: app\nprimary:\n  host: a\n  port: 1\nreplicas:\n  - host: b\n    port: -1\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: replicas[0]: port must not be negative")
+	})
+
+	t.Run("Validator Receives Whole Config", func(t *testing.T) {
+		cfg := TestConfigHooks{}
+		path := writeTempConfig(t, "name: app\nprimary:\n  host: a\n  port: 1\n")
+
+		var got *TestConfigHooks
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithValidator(func(config interface{}) error {
+			got = config.(*TestConfigHooks)
+			if got.Summary != "a:1" {
+				return fmt.Errorf("summary %q does not match", got.Summary)
+			}
+
+			return nil
+		}))
+		require.NoError(t, loadConfigErr)
+		require.Same(t, &cfg, got)
+	})
+
+	t.Run("Validator Runs After Field Checks", func(t *testing.T) {
+		cfg := TestConfigHooks{}
+		path := writeTempConfig(t, "primary:\n  host: a\n  port: 1\n")
+
+		called := false
+		validator := yamlconfig.WithValidator(func(config interface{}) error {
+			called = true
+			return errors.New("config_hash does not match the config")
+		})
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, validator), "failed to load the config: name: missing required config item")
+		require.False(t, called)
+
+		cfg = TestConfigHooks{}
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, validator, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: name: missing required config item; config_hash does not match the config")
+		require.True(t, called)
+	})
+}
@@ -0,0 +1,51 @@
+package yamlconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ImmutableChangedError is returned by Store.Reload when the new configuration
+// changes fields tagged yamlconfig:"immutable". Such fields keep the value they
+// were first loaded with for the life of the Store.
+type ImmutableChangedError struct {
+	// Paths holds the dotted paths of the changed fields, in field order.
+	Paths []string
+}
+
+// Error implements the error interface.
+func (e *ImmutableChangedError) Error() string {
+	return "immutable config items cannot change: " + strings.Join(e.Paths, ", ")
+}
+
+// immutableChangedPaths returns the paths of the fields that differ between
+// the structs old and updated and are tagged yamlconfig:"immutable", or lie
+// within a struct so tagged. The immutable flag reports whether an enclosing
+// struct is tagged.
+func immutableChangedPaths(old, updated reflect.Value, path string, immutable bool) []string {
+	var paths []string
+
+	for i := 0; i < old.NumField(); i++ {
+		key, inline, skip := yamlKey(old.Type().Field(i))
+		if skip {
+			continue
+		}
+
+		fieldPath := path
+		if !inline {
+			fieldPath = joinPath(path, key)
+		}
+
+		fieldImmutable := immutable || parseTag(old.Type().Field(i).Tag.Get("yamlconfig")).has("immutable")
+		oldField, updatedField := indirect(old.Field(i)), indirect(updated.Field(i))
+
+		switch {
+		case oldField.IsValid() && updatedField.IsValid() && oldField.Kind() == reflect.Struct:
+			paths = append(paths, immutableChangedPaths(oldField, updatedField, fieldPath, fieldImmutable)...)
+		case fieldImmutable && !reflect.DeepEqual(old.Field(i).Interface(), updated.Field(i).Interface()):
+			paths = append(paths, fieldPath)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,111 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// includeTag is the YAML tag that replaces a node with the contents of
+// another file.
+const includeTag = "!include"
+
+// hasIncludes reports whether data may hold an include tag, so documents
+// without one are not rewritten.
+func hasIncludes(data []byte) bool {
+	return bytes.Contains(data, []byte(includeTag))
+}
+
+// includeDir returns the directory relative includes are resolved against for
+// a document read from path. Documents not read from a file resolve them
+// against the working directory.
+func includeDir(path string) string {
+	if path == "" || path == stdinPath {
+		return "."
+	}
+
+	return filepath.Dir(path)
+}
+
+// includeStack returns the initial include stack for a document read from
+// path, so a file including itself is detected as a cycle.
+func includeStack(path string) []string {
+	if path == "" || path == stdinPath {
+		return nil
+	}
+
+	if abs, absErr := filepath.Abs(path); absErr == nil {
+		return []string{abs}
+	}
+
+	return nil
+}
+
+// expandIncludes replaces every scalar node tagged !include with the document
+// held in the named file. Relative paths are resolved against baseDir, and
+// files included by an included file against that file's directory. The
+// stack holds the absolute paths of the files being expanded, to detect
+// include cycles. The absolute path of each file read is passed to opened.
+func expandIncludes(node *yaml.Node, baseDir string, stack []string, opened func(path string)) error {
+	if node.Tag != includeTag {
+		for _, child := range node.Content {
+			if err := expandIncludes(child, baseDir, stack, opened); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if node.Kind != yaml.ScalarNode || node.Value == "" {
+		return fmt.Errorf("line %d: %s must be followed by a file path", node.Line, includeTag)
+	}
+
+	path := node.Value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	abs, absErr := filepath.Abs(path)
+	if absErr != nil {
+		return fmt.Errorf("line %d: failed to resolve include %q: %w", node.Line, node.Value, absErr)
+	}
+
+	for _, including := range stack {
+		if including == abs {
+			return fmt.Errorf("include cycle: %s", strings.Join(append(stack, abs), " -> "))
+		}
+	}
+
+	data, readErr := os.ReadFile(abs)
+	if readErr != nil {
+		return fmt.Errorf("line %d: failed to read include: %w", node.Line, readErr)
+	}
+
+	opened(abs)
+
+	var doc yaml.Node
+	if yamlUnmarshalErr := yaml.Unmarshal(stripBOM(data), &doc); yamlUnmarshalErr != nil {
+		return fmt.Errorf("failed to decode include %s: %w", node.Value, yamlUnmarshalErr)
+	}
+
+	// An empty file includes nothing, leaving the value null
+	if len(doc.Content) == 0 {
+		*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Line: node.Line, Column: node.Column}
+
+		return nil
+	}
+
+	included := doc.Content[0]
+	if err := expandIncludes(included, filepath.Dir(abs), append(stack, abs), opened); err != nil {
+		return err
+	}
+
+	*node = *included
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package yamlconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigInclude struct {
+	Name     string `yaml:"name"`
+	Database struct {
+		User string `yaml:"user"`
+		Port int    `yaml:"port"`
+	} `yaml:"database"`
+	Hosts []string `yaml:"hosts"`
+}
+
+// writeIncludeFiles writes each file into a new temporary directory and
+// returns the directory.
+func writeIncludeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestInclude(t *testing.T) {
+	t.Run("Include Files", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml":      "name: app\ndatabase: !include parts/db.yml\nhosts: !include parts/hosts.yml\n",
+			"parts/db.yml":    "user: app\nport: !include port.yml\n",
+			"parts/port.yml":  "5432\n",
+			"parts/hosts.yml": "- a\n- b\n",
+		})
+
+		cfg := TestConfigInclude{}
+		require.NoError(t, yamlconfig.LoadConfig(filepath.Join(dir, "config.yml"), &cfg))
+		require.Equal(t, "app", cfg.Database.User)
+		require.Equal(t, 5432, cfg.Database.Port)
+		require.Equal(t, []string{"a", "b"}, cfg.Hosts)
+	})
+
+	t.Run("Include Cycle", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include db.yml\n",
+			"db.yml":     "user: !include config.yml\n",
+		})
+
+		loadConfigErr := yamlconfig.LoadConfig(filepath.Join(dir, "config.yml"), &TestConfigInclude{})
+		require.ErrorContains(t, loadConfigErr, "include cycle: "+filepath.Join(dir, "config.yml")+" -> "+
+			filepath.Join(dir, "db.yml")+" -> "+filepath.Join(dir, "config.yml"))
+	})
+
+	t.Run("Include Missing File", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include missing.yml\n",
+		})
+
+		loadConfigErr := yamlconfig.LoadConfig(filepath.Join(dir, "config.yml"), &TestConfigInclude{})
+		require.ErrorContains(t, loadConfigErr, "line 2: failed to read include")
+	})
+
+	t.Run("Include Without Path", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include\n  user: app\n",
+		})
+
+		loadConfigErr := yamlconfig.LoadConfig(filepath.Join(dir, "config.yml"), &TestConfigInclude{})
+		require.ErrorContains(t, loadConfigErr, "line 2: !include must be followed by a file path")
+	})
+}
@@ -0,0 +1,148 @@
+package yamlconfig
+
+import "reflect"
+
+// Key describes a config item expected by a configuration struct.
+type Key struct {
+	// Path is the dotted YAML path of the item. Items inside slice elements
+	// are written as "servers[].address" and items inside map values as
+	// "backends.*.address". The keys held by an inlined map are written as
+	// the wildcard "*" under the struct's path.
+	Path string
+	// Type is the Go type of the field, such as "int" or "[]string".
+	// Anonymous struct types are shown as "struct".
+	Type string
+	// Required reports whether validation requires the item to be set, which
+	// is the case unless it is tagged yamlconfig:"omitempty".
+	Required bool
+	// Default is the value from the field's yamlconfig:"default=value" tag,
+	// or empty if there is none.
+	Default string
+}
+
+// String returns the path of the key.
+func (k Key) String() string {
+	return k.Path
+}
+
+// Keys lists every config item a configuration struct expects, derived from
+// its fields and tags without needing a configuration file. Nested structs are
+// listed before their own fields. It is intended for generating documentation
+// and sample configuration files.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+//
+// Returns:
+// []Key: The expected config items in struct field order.
+//
+// Example:
+//
+//	for _, key := range yamlconfig.Keys(&Config{}) {
+//	    fmt.Println(key.Path, key.Type, key.Required)
+//	}
+func Keys(config interface{}) []Key {
+	typ := reflect.TypeOf(config)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return appendKeys(nil, typ, "", map[reflect.Type]bool{})
+}
+
+// appendKeys appends the keys of the struct type typ, found under prefix. An
+// inlined map holds any keys the other fields leave over, so it is listed as
+// the optional wildcard key "*". Seen holds the struct types being listed
+// further up the path, so a type that refers to itself, such as a linked
+// list node, is listed once rather than forever.
+func appendKeys(keys []Key, typ reflect.Type, prefix string, seen map[reflect.Type]bool) []Key {
+	if seen[typ] {
+		return keys
+	}
+
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, inline, skip := yamlKey(field)
+		if skip {
+			continue
+		}
+
+		if inline {
+			switch fieldType := derefType(field.Type); fieldType.Kind() { //nolint:exhaustive // Only structs and maps can be inlined
+			case reflect.Struct:
+				keys = appendKeys(keys, fieldType, prefix, seen)
+			case reflect.Map:
+				keys = append(keys, Key{Path: joinPath(prefix, "*"), Type: describeType(fieldType.Elem())})
+			}
+
+			continue
+		}
+
+		tag := parseTag(field.Tag.Get("yamlconfig"))
+		defaultValue, _ := tag.get("default")
+		path := joinPath(prefix, name)
+
+		keys = append(keys, Key{
+			Path:     path,
+			Type:     describeType(field.Type),
+			Required: !tag.has("omitempty"),
+			Default:  defaultValue,
+		})
+
+		// Descend into the structs the field holds
+		fieldType := derefType(field.Type)
+
+		switch fieldType.Kind() { //nolint:exhaustive // Only containers of structs have nested keys
+		case reflect.Struct:
+			keys = appendKeys(keys, fieldType, path, seen)
+		case reflect.Slice, reflect.Array:
+			if elem := derefType(fieldType.Elem()); elem.Kind() == reflect.Struct {
+				keys = appendKeys(keys, elem, path+"[]", seen)
+			}
+		case reflect.Map:
+			if elem := derefType(fieldType.Elem()); elem.Kind() == reflect.Struct {
+				keys = appendKeys(keys, elem, path+".*", seen)
+			}
+		}
+	}
+
+	return keys
+}
+
+// derefType returns the type pointed to by typ, following any number of
+// pointers.
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// describeType returns the Go type as a string, shortening anonymous struct
+// types to "struct" so they stay readable.
+func describeType(typ reflect.Type) string {
+	switch typ.Kind() { //nolint:exhaustive // Only composite kinds can hold anonymous structs
+	case reflect.Ptr:
+		return "*" + describeType(typ.Elem())
+	case reflect.Slice:
+		return "[]" + describeType(typ.Elem())
+	case reflect.Map:
+		return "map[" + describeType(typ.Key()) + "]" + describeType(typ.Elem())
+	case reflect.Struct:
+		if typ.Name() == "" {
+			return "struct"
+		}
+	}
+
+	return typ.String()
+}
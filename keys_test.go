@@ -0,0 +1,78 @@
+package yamlconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigKeys struct {
+	Name    string        `yaml:"name"`
+	Timeout time.Duration `yaml:"timeout" yamlconfig:"default=5s"`
+	Servers []struct {
+		Address string `yaml:"address"`
+	} `yaml:"servers"`
+	TLS *struct {
+		Cert string `yaml:"cert" yamlconfig:"omitempty"`
+	} `yaml:"tls" yamlconfig:"omitempty"`
+	Ignored string `yaml:"-"`
+}
+
+type TestConfigListNode struct {
+	Name     string               `yaml:"name"`
+	Next     *TestConfigListNode  `yaml:"next" yamlconfig:"omitempty"`
+	Children []TestConfigListNode `yaml:"children" yamlconfig:"omitempty"`
+}
+
+func TestKeys(t *testing.T) {
+	t.Run("Keys Lists Every Item", func(t *testing.T) {
+		keys := yamlconfig.Keys(&TestConfigKeys{})
+
+		paths := make([]string, len(keys))
+		for i, key := range keys {
+			paths[i] = key.String()
+		}
+
+		require.Equal(t, []string{"name", "timeout", "servers", "servers[].address", "tls", "tls.cert"}, paths)
+		require.Equal(t, yamlconfig.Key{Path: "timeout", Type: "time.Duration", Required: true, Default: "5s"}, keys[1])
+		require.True(t, keys[3].Required)
+		require.False(t, keys[4].Required)
+		require.False(t, keys[5].Required)
+	})
+
+	t.Run("Keys Of Non Struct", func(t *testing.T) {
+		require.Nil(t, yamlconfig.Keys("config"))
+	})
+
+	t.Run("Keys Of Inline Map", func(t *testing.T) {
+		keys := yamlconfig.Keys(&struct {
+			Name  string            `yaml:"name"`
+			Extra map[string]string `yaml:",inline"`
+		}{})
+
+		require.Equal(t, []yamlconfig.Key{
+			{Path: "name", Type: "string", Required: true},
+			{Path: "*", Type: "string"},
+		}, keys)
+	})
+
+	t.Run("Keys Of Recursive Type", func(t *testing.T) {
+		keys := yamlconfig.Keys(&struct {
+			Head TestConfigListNode `yaml:"head"`
+			Tail TestConfigListNode `yaml:"tail"`
+		}{})
+
+		require.Equal(t, []yamlconfig.Key{
+			{Path: "head", Type: "yamlconfig_test.TestConfigListNode", Required: true},
+			{Path: "head.name", Type: "string", Required: true},
+			{Path: "head.next", Type: "*yamlconfig_test.TestConfigListNode"},
+			{Path: "head.children", Type: "[]yamlconfig_test.TestConfigListNode"},
+			{Path: "tail", Type: "yamlconfig_test.TestConfigListNode", Required: true},
+			{Path: "tail.name", Type: "string", Required: true},
+			{Path: "tail.next", Type: "*yamlconfig_test.TestConfigListNode"},
+			{Path: "tail.children", Type: "[]yamlconfig_test.TestConfigListNode"},
+		}, keys)
+	})
+}
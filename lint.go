@@ -0,0 +1,205 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// LintRule names a hygiene check run on the raw file by WithLint.
+type LintRule int
+
+const (
+	// LintTrailingWhitespace reports lines ending in spaces or tabs.
+	LintTrailingWhitespace LintRule = iota + 1
+	// LintIndentation reports lines whose indentation steps in by a different
+	// number of spaces than the first indented line of the file.
+	LintIndentation
+	// LintFinalNewline reports a file that does not end with a newline.
+	LintFinalNewline
+)
+
+// LintError describes a line of the file that breaks a lint rule.
+type LintError struct {
+	// Line is the 1-based line number.
+	Line int
+	// Rule is the rule that was broken.
+	Rule LintRule
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *LintError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// WithLint checks the raw file against the given rules before it is decoded,
+// so the loader can double as a lightweight YAML linter in CI. With no rules
+// every rule is checked. All problems found are returned together in a
+// MultiError of *LintError values, and the file is not decoded.
+//
+// Example:
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithLint())
+//	// failed to lint config file: line 3: trailing whitespace; line 9: no newline at end of file
+func WithLint(rules ...LintRule) Option {
+	return func(o *options) {
+		if len(rules) == 0 {
+			rules = []LintRule{LintTrailingWhitespace, LintIndentation, LintFinalNewline}
+		}
+
+		o.lintRules = rules
+	}
+}
+
+// lint checks data against the rules, returning every problem found in a
+// MultiError or nil if there are none.
+func lint(data []byte, rules []LintRule) error {
+	var errs []error
+
+	for _, rule := range rules {
+		switch rule {
+		case LintTrailingWhitespace:
+			errs = append(errs, lintTrailingWhitespace(data)...)
+		case LintIndentation:
+			errs = append(errs, lintIndentation(data)...)
+		case LintFinalNewline:
+			if len(data) > 0 && data[len(data)-1] != '\n' {
+				errs = append(errs, &LintError{Line: bytes.Count(data, []byte("\n")) + 1, Rule: rule, Message: "no newline at end of file"})
+			}
+		default:
+			return fmt.Errorf("failed to lint config file: unknown lint rule %d", rule)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("failed to lint config file: %w", &MultiError{Errors: errs})
+}
+
+// lintLines splits data into lines without their line endings.
+func lintLines(data []byte) []string {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return lines
+}
+
+// lintTrailingWhitespace reports every line ending in spaces or tabs.
+func lintTrailingWhitespace(data []byte) []error {
+	var errs []error
+
+	for i, line := range lintLines(data) {
+		if strings.TrimRight(line, " \t") != line {
+			errs = append(errs, &LintError{Line: i + 1, Rule: LintTrailingWhitespace, Message: "trailing whitespace"})
+		}
+	}
+
+	return errs
+}
+
+// lintIndentation reports every line that steps in from its parent by a
+// different number of spaces than the first indented line did. The items of
+// a sequence count as indented to where their content starts, so the keys of
+// a mapping within an item line up with the first. Blank lines, comments,
+// lines indented with tabs and the contents of block scalars are skipped.
+func lintIndentation(data []byte) []error {
+	var (
+		errs        []error
+		width       int
+		levels      = []int{0}
+		blockIndent = -1
+	)
+
+	for i, line := range lintLines(data) {
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+
+		if content == "" || strings.HasPrefix(content, "#") || strings.HasPrefix(content, "\t") {
+			continue
+		}
+
+		// Lines indented beneath a block scalar indicator are its content
+		if blockIndent >= 0 {
+			if indent > blockIndent {
+				continue
+			}
+
+			blockIndent = -1
+		}
+
+		for len(levels) > 1 && levels[len(levels)-1] > indent {
+			levels = levels[:len(levels)-1]
+		}
+
+		if step := indent - levels[len(levels)-1]; step > 0 {
+			if width == 0 {
+				width = step
+			}
+
+			if step != width {
+				errs = append(errs, &LintError{Line: i + 1, Rule: LintIndentation, Message: fmt.Sprintf("indented by %d spaces, expected %d", step, width)})
+			}
+
+			levels = append(levels, indent)
+		}
+
+		// The content of a sequence item starts after its dash
+		lineIndent := indent
+		for strings.HasPrefix(content, "- ") {
+			rest := strings.TrimLeft(content[1:], " ")
+			indent += len(content) - len(rest)
+			content = rest
+			levels = append(levels, indent)
+		}
+
+		// A block scalar's content is indented beyond the node holding it
+		if found, whole := blockScalarIndicator(content); found {
+			blockIndent = levels[len(levels)-1]
+			if whole {
+				blockIndent = lineIndent
+			}
+		}
+	}
+
+	return errs
+}
+
+// blockScalarIndicator reports whether the line content ends with a literal or
+// folded block scalar indicator, such as "script: |" or "text: >-", and
+// whether the indicator is the whole of the content.
+func blockScalarIndicator(content string) (found, whole bool) {
+	if hash := strings.Index(content, " #"); hash >= 0 {
+		content = content[:hash]
+	}
+
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return false, false
+	}
+
+	last := fields[len(fields)-1]
+	if (last[0] != '|' && last[0] != '>') || strings.Trim(last[1:], "+-0123456789") != "" {
+		return false, false
+	}
+
+	return true, len(fields) == 1
+}
+
+// tabIndentedLine returns the 1-based number of the first line whose
+// indentation contains a tab character, or 0 if there is none.
+func tabIndentedLine(data []byte) int {
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+		if bytes.IndexByte(indent, '\t') >= 0 && len(bytes.TrimSpace(line)) > 0 {
+			return i + 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,77 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigLint struct {
+	Name    string `yaml:"name"`
+	Servers []struct {
+		Host string   `yaml:"host"`
+		Port int      `yaml:"port"`
+		Tags []string `yaml:"tags" yamlconfig:"omitempty"`
+	} `yaml:"servers"`
+	Script string   `yaml:"script" yamlconfig:"omitempty"`
+	Notes  []string `yaml:"notes" yamlconfig:"omitempty"`
+}
+
+func TestLint(t *testing.T) {
+	t.Run("Tab Indentation", func(t *testing.T) {
+		cfg := TestConfigEmptyStruct{}
+		path := writeTempConfig(t, "string: test\nstruct:\n\tstring: test\n\tint: 1\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to decode config file: line 3: tabs are not allowed for indentation in YAML")
+	})
+
+	t.Run("Tabs Inside Values Are Allowed", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "string: \"a\\tb\"\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "a\tb", cfg.String)
+	})
+
+	t.Run("Lint Clean File", func(t *testing.T) {
+		cfg := TestConfigLint{}
+		path := writeTempConfig(t, "# servers\nname: app\nservers:\n  - host: a\n    port: 80\n    tags:\n      - web\n  - host: b\n    port: 81\nscript: |\n  echo one\n      echo indented\nnotes:\n  - |\n     first\n  - >-\n    second\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLint()))
+		require.Equal(t, "echo one\n    echo indented\n", cfg.Script)
+	})
+
+	t.Run("Lint Reports Every Problem", func(t *testing.T) {
+		cfg := TestConfigLint{}
+		path := writeTempConfig(t, "name: app \nservers:\n    - host: a\n      port: 80\nscript: x\t\nnotes:\n  - a")
+
+		loadErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLint())
+		require.EqualError(t, loadErr, "failed to lint config file: line 1: trailing whitespace; line 5: trailing whitespace; "+
+			"line 7: indented by 2 spaces, expected 4; line 7: no newline at end of file")
+
+		var lintErr *yamlconfig.LintError
+		require.True(t, errors.As(loadErr, &lintErr))
+		require.Equal(t, 1, lintErr.Line)
+		require.Equal(t, yamlconfig.LintTrailingWhitespace, lintErr.Rule)
+		require.Empty(t, cfg.Name)
+	})
+
+	t.Run("Lint Selected Rules", func(t *testing.T) {
+		cfg := TestConfigLint{}
+		path := writeTempConfig(t, "name: app \nservers:\n   - host: a\n     port: 80")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLint(yamlconfig.LintFinalNewline)),
+			"failed to lint config file: line 4: no newline at end of file")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLint(yamlconfig.LintIndentation)))
+	})
+
+	t.Run("Lint Off By Default", func(t *testing.T) {
+		cfg := TestConfigLint{}
+		path := writeTempConfig(t, "name: app \nservers:\n   - host: a\n     port: 80")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "app", cfg.Name)
+	})
+}
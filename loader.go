@@ -0,0 +1,260 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Loader loads a configuration file with a fixed set of options and keeps
+// track of what it learned about the document during the last load. A Loader
+// caches the last successfully loaded configuration and only reads the file
+// again once its modification time or size, or that of a file it pulled in
+// with !include or a fromfile field, changes. It is safe for concurrent use.
+type Loader struct {
+	mu   sync.Mutex
+	path string
+	opts *options
+	// secretPaths holds the document paths whose values came from an anchor
+	// registered with WithSecretAnchors.
+	secretPaths map[string]bool
+	// cached points to a copy of the last successfully loaded configuration,
+	// read from files in the state recorded in files.
+	cached reflect.Value
+	// files holds the state of the configuration file and of every file that
+	// contributed to the cached configuration, keyed by path.
+	files map[string]fileState
+	// doc is the document the cached configuration was decoded from, which
+	// Reload compares against to validate only what changed.
+	doc *yaml.Node
+	// patched is doc extended with the keys of the patches and overrides
+	// applied since the last load, or nil if there are none.
+	patched *yaml.Node
+}
+
+// NewLoader returns a Loader for the configuration file at path.
+//
+// Example:
+//
+// loader := yamlconfig.NewLoader("config.yml", yamlconfig.WithSecretAnchors("db_password"))
+// err := loader.Load(&cfg)
+func NewLoader(path string, opts ...Option) *Loader {
+	return &Loader{
+		path: path,
+		opts: newOptions(opts),
+	}
+}
+
+// Load loads, decodes and validates the configuration file into the provided
+// struct pointer, in the same way as LoadConfig. If neither the file nor any
+// file it includes or reads through a fromfile field has changed since the
+// last successful load, the cached configuration is copied into config
+// instead. Changes are detected by modification time and size, so a file
+// rewritten with the same size within the file system's timestamp resolution
+// is not noticed; use Reload to read the files regardless. The copy is shallow, so slices and maps are shared with the
+// cache and should not be modified.
+func (l *Loader) Load(config interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cached.IsValid() && l.cached.Type() == reflect.TypeOf(config) && filesUnchanged(l.files) {
+		reflect.ValueOf(config).Elem().Set(l.cached.Elem())
+		l.patched = nil
+
+		return nil
+	}
+
+	return l.opts.logResult(l.path, l.load(config, nil))
+}
+
+// Reload loads the configuration file into the provided struct pointer even if
+// it has not changed since the last load, refreshing the cache.
+//
+// After a successful load of the same config type, Reload compares the new
+// document with the previous one and only validates the structs holding, or
+// lying within, a value that changed. Every field of a struct holding a
+// change is checked, so rules relating the changed field to its siblings
+// still apply, and validators registered with WithValidator always run. The
+// whole config is validated when the documents use merge keys, or when its
+// types read fields from other files or the environment or implement
+// AfterDecoder, since their values can change without the document changing.
+func (l *Loader) Reload(config interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var previous *yaml.Node
+	if l.cached.IsValid() && l.cached.Type() == reflect.TypeOf(config) {
+		previous = l.doc
+	}
+
+	return l.opts.logResult(l.path, l.load(config, previous))
+}
+
+// load performs the work of Load and Reload, validating only what changed
+// since the previous document when it is not nil. The caller must hold l.mu.
+func (l *Loader) load(config interface{}, previous *yaml.Node) error {
+	l.cached = reflect.Value{}
+	l.doc = nil
+	l.patched = nil
+
+	// Record the file's state before reading it, so a change made while
+	// loading is picked up by the next call
+	info, statErr := os.Stat(l.path)
+	if statErr != nil {
+		return fmt.Errorf("failed to load config file: %w", statErr)
+	}
+
+	// Read the configuration file
+	data, fileErr := os.ReadFile(l.path)
+	if fileErr != nil {
+		return fmt.Errorf("failed to load config file: %w", fileErr)
+	}
+
+	l.opts.logger(LoadEvent{Phase: PhaseOpened, Path: l.path, Message: "opened config file"})
+
+	// Note every other file read while loading, passing each on to the
+	// caller's WithSources slice too
+	var sources []string
+
+	o := *l.opts
+	o.sources = &sources
+
+	defer func() {
+		for _, source := range sources {
+			l.opts.addSource(source)
+		}
+	}()
+
+	doc, decodeErr := decodeDocument(data, l.path, config, &o, previous)
+	if decodeErr != nil {
+		return decodeErr
+	}
+
+	// Remember where the secret anchors were used in the processed document,
+	// which holds the content of included files too
+	l.secretPaths = map[string]bool{}
+
+	if len(l.opts.secretAnchors) > 0 {
+		collectAnchorPaths(doc, "", l.opts.secretAnchors, l.secretPaths)
+	}
+
+	// Cache a copy of the loaded configuration
+	val := reflect.ValueOf(config)
+	l.cached = reflect.New(val.Type().Elem())
+	l.cached.Elem().Set(val.Elem())
+	l.files = map[string]fileState{l.path: {modTime: info.ModTime(), size: info.Size()}}
+	l.doc = doc
+
+	for _, source := range sources {
+		if sourceInfo, sourceErr := os.Stat(source); sourceErr == nil {
+			l.files[source] = fileState{modTime: sourceInfo.ModTime(), size: sourceInfo.Size()}
+		} else {
+			l.files[source] = fileState{}
+		}
+	}
+
+	return nil
+}
+
+// fileState is the modification time and size of a file when it was read.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// filesUnchanged reports whether every file still has the recorded state. A
+// file that can no longer be read counts as changed.
+func filesUnchanged(files map[string]fileState) bool {
+	for path, state := range files {
+		info, statErr := os.Stat(path)
+		if statErr != nil || !info.ModTime().Equal(state.modTime) || info.Size() != state.size {
+			return false
+		}
+	}
+
+	return len(files) > 0
+}
+
+// presence returns the document whose keys count as present in config, which
+// is the document of the last load extended by the patches and overrides
+// applied since, or nil if config is not of the type last loaded.
+func (l *Loader) presence(config interface{}) *yaml.Node {
+	if !l.cached.IsValid() || l.cached.Type() != reflect.TypeOf(config) {
+		return nil
+	}
+
+	if l.patched != nil {
+		return l.patched
+	}
+
+	return l.doc
+}
+
+// DumpRedacted marshals the configuration like the package level DumpRedacted,
+// additionally redacting every value that was defined by, or aliased from, an
+// anchor registered with WithSecretAnchors during the last Load.
+func (l *Loader) DumpRedacted(config interface{}) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return dumpRedacted(config, l.secretPaths)
+}
+
+// collectAnchorPaths records the path of every node that defines one of the
+// secret anchors or is an alias of one.
+func collectAnchorPaths(node *yaml.Node, path string, anchors map[string]bool, paths map[string]bool) {
+	if node == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectAnchorPaths(child, path, anchors, paths)
+		}
+
+		return
+	case yaml.AliasNode:
+		if node.Alias != nil && anchors[node.Alias.Anchor] {
+			paths[path] = true
+		}
+
+		return
+	case yaml.MappingNode:
+		if anchors[node.Anchor] {
+			paths[path] = true
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			// A merge key copies the keys of the aliased mapping into this one
+			if key.Value == "<<" && value.Kind == yaml.AliasNode && value.Alias != nil && anchors[value.Alias.Anchor] {
+				for j := 0; j+1 < len(value.Alias.Content); j += 2 {
+					paths[joinPath(path, value.Alias.Content[j].Value)] = true
+				}
+
+				continue
+			}
+
+			collectAnchorPaths(value, joinPath(path, key.Value), anchors, paths)
+		}
+	case yaml.SequenceNode:
+		if anchors[node.Anchor] {
+			paths[path] = true
+		}
+
+		for i, item := range node.Content {
+			collectAnchorPaths(item, path+"["+strconv.Itoa(i)+"]", anchors, paths)
+		}
+	case yaml.ScalarNode:
+		if anchors[node.Anchor] {
+			paths[path] = true
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package yamlconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader(t *testing.T) {
+	t.Run("Loader Load", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "string: test\n")
+
+		require.NoError(t, yamlconfig.NewLoader(path).Load(&cfg))
+		require.Equal(t, "test", cfg.String)
+	})
+
+	t.Run("Loader Dump Redacted Secret Anchors", func(t *testing.T) {
+		cfg := TestConfigSecret{}
+		path := writeTempConfig(t, "database:\n  user: app\n  password: &pw s3cret\ncache:\n  password: *pw\n")
+
+		loader := yamlconfig.NewLoader(path, yamlconfig.WithSecretAnchors("pw"))
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "s3cret", cfg.Cache.Password)
+
+		out, dumpErr := loader.DumpRedacted(&cfg)
+		require.NoError(t, dumpErr)
+		require.NotContains(t, string(out), "s3cret")
+		require.Contains(t, string(out), "cache:\n    password: REDACTED\n")
+	})
+
+	t.Run("Loader Dump Redacted Merged Secret Anchor", func(t *testing.T) {
+		cfg := TestConfigSecret{}
+		path := writeTempConfig(t, "creds: &creds\n  password: s3cret\ndatabase:\n  user: app\n  password: other\ncache:\n  <<: *creds\n")
+
+		loader := yamlconfig.NewLoader(path, yamlconfig.WithSecretAnchors("creds"))
+		require.NoError(t, loader.Load(&cfg))
+
+		out, dumpErr := loader.DumpRedacted(&cfg)
+		require.NoError(t, dumpErr)
+		require.NotContains(t, string(out), "s3cret")
+	})
+
+	t.Run("Loader Dump Redacted Included Secret Anchor", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include db.yml\n",
+			"db.yml":     "user: app\ntoken: &token s3cret\nbackup: *token\n",
+		})
+
+		cfg := struct {
+			Name     string `yaml:"name"`
+			Database struct {
+				User   string `yaml:"user"`
+				Token  string `yaml:"token"`
+				Backup string `yaml:"backup"`
+			} `yaml:"database"`
+		}{}
+
+		loader := yamlconfig.NewLoader(filepath.Join(dir, "config.yml"), yamlconfig.WithSecretAnchors("token"))
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "s3cret", cfg.Database.Backup)
+
+		out, dumpErr := loader.DumpRedacted(&cfg)
+		require.NoError(t, dumpErr)
+		require.NotContains(t, string(out), "s3cret")
+		require.Contains(t, string(out), "user: app")
+	})
+
+	t.Run("Loader Caches Until File Changes", func(t *testing.T) {
+		path := writeTempConfig(t, "string: first\n")
+
+		var opened int
+		loader := yamlconfig.NewLoader(path, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseOpened {
+				opened++
+			}
+		}))
+
+		cfg := TestConfigEmpty{}
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "first", cfg.String)
+
+		cached := TestConfigEmpty{}
+		require.NoError(t, loader.Load(&cached))
+		require.Equal(t, "first", cached.String)
+		require.Equal(t, 1, opened)
+
+		require.NoError(t, os.WriteFile(path, []byte("string: second\n"), 0o600))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "second", cfg.String)
+		require.Equal(t, 2, opened)
+	})
+
+	t.Run("Loader Reads Again When Included Files Change", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include db.yml\n",
+			"password":   "one\n",
+		})
+		passwordPath := filepath.Join(dir, "password")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "db.yml"), []byte("user: first\npassword_file: "+passwordPath+"\n"), 0o600))
+
+		var sources []string
+
+		loader := yamlconfig.NewLoader(filepath.Join(dir, "config.yml"), yamlconfig.WithSources(&sources))
+
+		cfg := TestConfigSources{}
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "first", cfg.Database.User)
+		require.Equal(t, []string{filepath.Join(dir, "db.yml"), passwordPath}, sources)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "db.yml"), []byte("user: second\npassword_file: "+passwordPath+"\n"), 0o600))
+		require.NoError(t, os.Chtimes(filepath.Join(dir, "db.yml"), time.Now(), time.Now().Add(time.Minute)))
+		cfg = TestConfigSources{}
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "second", cfg.Database.User)
+
+		require.NoError(t, os.WriteFile(passwordPath, []byte("two\n"), 0o600))
+		require.NoError(t, os.Chtimes(passwordPath, time.Now(), time.Now().Add(time.Minute)))
+		cfg = TestConfigSources{}
+		require.NoError(t, loader.Load(&cfg))
+		require.Equal(t, "two", cfg.Database.Password)
+	})
+
+	t.Run("Loader Reload Forces Read", func(t *testing.T) {
+		path := writeTempConfig(t, "string: first\n")
+
+		var opened int
+		loader := yamlconfig.NewLoader(path, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseOpened {
+				opened++
+			}
+		}))
+
+		cfg := TestConfigEmpty{}
+		require.NoError(t, loader.Load(&cfg))
+		require.NoError(t, loader.Reload(&cfg))
+		require.Equal(t, 2, opened)
+	})
+
+	t.Run("Loader Reload Validates Only Changes", func(t *testing.T) {
+		type endpoint struct {
+			Host string `yaml:"host"`
+			Port int    `yaml:"port" yamlconfig:"max=65535"`
+		}
+
+		type config struct {
+			API endpoint `yaml:"api"`
+			Web endpoint `yaml:"web"`
+		}
+
+		path := writeTempConfig(t, "api:\n  host: a\n  port: 1\nweb:\n  host: b\n  port: 2\n")
+
+		var fields []int
+		loader := yamlconfig.NewLoader(path, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseValidated {
+				fields = append(fields, e.Fields)
+			}
+		}))
+
+		cfg := config{}
+		require.NoError(t, loader.Load(&cfg))
+		require.NoError(t, loader.Reload(&cfg))
+
+		require.NoError(t, os.WriteFile(path, []byte("api:\n  host: a\n  port: 1\nweb:\n  host: b\n  port: 3\n"), 0o600))
+		require.NoError(t, loader.Reload(&cfg))
+		require.Equal(t, 3, cfg.Web.Port)
+		require.Equal(t, []int{6, 0, 4}, fields)
+
+		require.NoError(t, os.WriteFile(path, []byte("api:\n  host: a\n  port: 1\nweb:\n  port: 70000\n"), 0o600))
+		cfg = config{}
+		require.EqualError(t, loader.Reload(&cfg), "failed to load the config: web.host: missing required config item")
+	})
+
+	t.Run("Loader Reload Checks Sibling Rules", func(t *testing.T) {
+		type config struct {
+			Name      string `yaml:"name"`
+			ProxyHost string `yaml:"proxy_host" yamlconfig:"omitempty,together=proxy_port"`
+			ProxyPort int    `yaml:"proxy_port" yamlconfig:"omitempty"`
+		}
+
+		path := writeTempConfig(t, "name: app\nproxy_host: proxy\nproxy_port: 3128\n")
+		loader := yamlconfig.NewLoader(path)
+
+		cfg := config{}
+		require.NoError(t, loader.Load(&cfg))
+
+		require.NoError(t, os.WriteFile(path, []byte("name: app\nproxy_host: proxy\n"), 0o600))
+		cfg = config{}
+		require.EqualError(t, loader.Reload(&cfg), "failed to load the config: proxy_host: is set but proxy_port is not, they must be set together")
+	})
+
+	t.Run("Loader Reload Falls Back To Full Validation", func(t *testing.T) {
+		type endpoint struct {
+			Host string `yaml:"host"`
+		}
+
+		type config struct {
+			API endpoint `yaml:"api"`
+			Web endpoint `yaml:"web"`
+		}
+
+		content := "base: &base\n  host: a\napi:\n  <<: *base\nweb:\n  host: b\n"
+		path := writeTempConfig(t, content)
+
+		var fields []int
+		loader := yamlconfig.NewLoader(path, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseValidated {
+				fields = append(fields, e.Fields)
+			}
+		}))
+
+		cfg := config{}
+		require.NoError(t, loader.Load(&cfg))
+		require.NoError(t, loader.Reload(&cfg))
+		require.Equal(t, []int{4, 4}, fields)
+	})
+}
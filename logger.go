@@ -0,0 +1,49 @@
+package yamlconfig
+
+// LoadPhase identifies the stage of the loading process a LoadEvent describes.
+type LoadPhase string
+
+const (
+	// PhaseOpened is emitted once the configuration file has been opened.
+	PhaseOpened LoadPhase = "opened"
+	// PhaseDecoded is emitted once the YAML content has been decoded.
+	PhaseDecoded LoadPhase = "decoded"
+	// PhaseValidated is emitted once the decoded configuration has passed
+	// validation.
+	PhaseValidated LoadPhase = "validated"
+	// PhaseWarning is emitted for a problem that was tolerated rather than
+	// stopping the load, such as those allowed by WithDecodeWarnings.
+	PhaseWarning LoadPhase = "warning"
+	// PhaseFailed is emitted when loading stops because of an error.
+	PhaseFailed LoadPhase = "failed"
+)
+
+// LoadEvent describes a single step of loading a configuration file. It is
+// passed to the function registered with WithLogger.
+type LoadEvent struct {
+	// Phase is the stage of loading the event describes.
+	Phase LoadPhase
+	// Path is the path of the configuration file being loaded.
+	Path string
+	// Fields is the number of fields checked during validation. It is only
+	// set for PhaseValidated events.
+	Fields int
+	// Message is a human-readable description of the event.
+	Message string
+	// Err is the error that stopped loading. It is only set for PhaseFailed
+	// events.
+	Err error
+}
+
+// logResult records the outcome of a load with the metrics sink and emits a
+// PhaseFailed event when err is not nil. It returns err unchanged, so loader
+// functions can report their outcome in one place.
+func (o *options) logResult(path string, err error) error {
+	o.metrics.RecordLoad(path, loadOutcome(err))
+
+	if err != nil {
+		o.logger(LoadEvent{Phase: PhaseFailed, Path: path, Message: "failed to load config", Err: err})
+	}
+
+	return err
+}
@@ -0,0 +1,43 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("Load Config Emits Events", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "string: test\n")
+
+		var events []yamlconfig.LoadEvent
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			events = append(events, e)
+		}))
+		require.NoError(t, loadConfigErr)
+
+		require.Len(t, events, 3)
+		require.Equal(t, yamlconfig.PhaseOpened, events[0].Phase)
+		require.Equal(t, yamlconfig.PhaseDecoded, events[1].Phase)
+		require.Equal(t, yamlconfig.PhaseValidated, events[2].Phase)
+		require.Equal(t, 1, events[2].Fields)
+		require.Equal(t, path, events[2].Path)
+	})
+
+	t.Run("Load Config Emits Failure Event", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "int: 1\n")
+
+		var events []yamlconfig.LoadEvent
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			events = append(events, e)
+		}))
+		require.Error(t, loadConfigErr)
+
+		last := events[len(events)-1]
+		require.Equal(t, yamlconfig.PhaseFailed, last.Phase)
+		require.Equal(t, loadConfigErr, last.Err)
+	})
+}
@@ -0,0 +1,67 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithLowerMapKeys lowercases the keys of every map with string keys once the
+// file is decoded, as though each map field were tagged
+// yamlconfig:"lowerkeys". Use it when keys such as HTTP header names are
+// compared without regard to case.
+func WithLowerMapKeys() Option {
+	return func(o *options) {
+		o.lowerMapKeys = true
+	}
+}
+
+// applyLowerKeys lowercases the keys of the map fields tagged
+// yamlconfig:"lowerkeys", or of every map with string keys when all is set,
+// including a map at the root. Tagged maps whose keys are not strings are
+// reported to errs.
+func applyLowerKeys(val reflect.Value, all bool, errs *errorList) error {
+	if root := indirect(val); all && root.IsValid() && root.Kind() == reflect.Map && root.Type().Key().Kind() == reflect.String {
+		lowerKeys(root)
+	}
+
+	return walkFields(val, "", func(field reflect.Value, typ reflect.StructField, path string) error {
+		tagged := parseTag(typ.Tag.Get("yamlconfig")).has("lowerkeys")
+		if !tagged && !all {
+			return nil
+		}
+
+		switch {
+		case field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String:
+			lowerKeys(field)
+		case tagged:
+			return errs.add(fmt.Errorf("lowerkeys is only supported on maps with string keys: %s", path))
+		}
+
+		return nil
+	})
+}
+
+// lowerKeys replaces the keys of the map with their lowercase form. When
+// several keys fold to the same one, the value of the key that was already
+// lowercase is kept, or otherwise that of the key sorting first.
+func lowerKeys(field reflect.Value) {
+	keys := field.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	lowered := reflect.MakeMapWithSize(field.Type(), len(keys))
+
+	for _, key := range keys {
+		lower := reflect.ValueOf(strings.ToLower(key.String())).Convert(field.Type().Key())
+		if lowered.MapIndex(lower).IsValid() && key.String() != lower.String() {
+			continue
+		}
+
+		lowered.SetMapIndex(lower, field.MapIndex(key))
+	}
+
+	field.Set(lowered)
+}
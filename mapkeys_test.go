@@ -0,0 +1,57 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigLowerKeys struct {
+	Headers map[string]string `yaml:"headers" yamlconfig:"lowerkeys,requiredkeys=content-type"`
+	Labels  map[string]string `yaml:"labels" yamlconfig:"omitempty"`
+}
+
+func TestLowerKeys(t *testing.T) {
+	t.Run("Tagged Map Keys Lowercased", func(t *testing.T) {
+		cfg := TestConfigLowerKeys{}
+		path := writeTempConfig(t, "headers:\n  Content-Type: json\n  X-Request-ID: abc\nlabels:\n  Team: core\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, map[string]string{"content-type": "json", "x-request-id": "abc"}, cfg.Headers)
+		require.Equal(t, map[string]string{"Team": "core"}, cfg.Labels)
+	})
+
+	t.Run("Every Map Lowercased With Option", func(t *testing.T) {
+		cfg := TestConfigLowerKeys{}
+		path := writeTempConfig(t, "headers:\n  Content-Type: json\nlabels:\n  Team: core\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLowerMapKeys()))
+		require.Equal(t, map[string]string{"team": "core"}, cfg.Labels)
+	})
+
+	t.Run("Colliding Keys Merged Deterministically", func(t *testing.T) {
+		cfg := TestConfigLowerKeys{}
+		path := writeTempConfig(t, "headers:\n  Content-Type: json\n  content-type: yaml\n  ACCEPT: a\n  Accept: b\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, map[string]string{"content-type": "yaml", "accept": "a"}, cfg.Headers)
+	})
+
+	t.Run("Map Root Lowercased With Option", func(t *testing.T) {
+		cfg := map[string]string{}
+		path := writeTempConfig(t, "Host: a\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithLowerMapKeys()))
+		require.Equal(t, map[string]string{"host": "a"}, cfg)
+	})
+
+	t.Run("Unsupported Field Type", func(t *testing.T) {
+		cfg := struct {
+			Ports map[int]string `yaml:"ports" yamlconfig:"lowerkeys"`
+		}{}
+		path := writeTempConfig(t, "ports:\n  80: http\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: lowerkeys is only supported on maps with string keys: ports")
+	})
+}
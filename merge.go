@@ -0,0 +1,193 @@
+package yamlconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithValidateEachFile makes the loaders that merge several files, such as
+// LoadConfigEnv and LoadConfigSearch with WithSearchMergeAll, also validate
+// every file on its own before merging it, for setups where each layer must
+// be complete. By default only the merged configuration is validated, so a
+// base file may leave out values that an overlay provides.
+func WithValidateEachFile() Option {
+	return func(o *options) {
+		o.validateEachFile = true
+	}
+}
+
+// LoadConfigEnv loads a base configuration file and deep-merges an
+// environment specific overlay on top of it before validating the result.
+// The overlay is the sibling file with the environment name inserted before
+// the extension, so "config.yaml" with env "production" is overlaid by
+// "config.production.yaml". A missing overlay file is not an error. Validation
+// runs once on the merged configuration, so the base file may leave out
+// values that the overlay provides, unless WithValidateEachFile is used.
+//
+// Parameters:
+//
+// basePath: The path to the base configuration file.
+// env: The name of the environment whose overlay should be applied.
+// config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
+//
+// Returns:
+// error: An error if a configuration file could not be loaded or decoded, or
+// the merged configuration is not valid.
+//
+// Example:
+//
+// err := yamlconfig.LoadConfigEnv("config.yaml", os.Getenv("APP_ENV"), &cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func LoadConfigEnv(basePath, env string, config interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	return o.logResult(basePath, loadConfigEnv(basePath, env, config, o))
+}
+
+// loadConfigEnv performs the work of LoadConfigEnv using already resolved
+// options.
+func loadConfigEnv(basePath, env string, config interface{}, o *options) error {
+	paths := []string{basePath}
+	if env != "" {
+		paths = append(paths, envOverlayPath(basePath, env))
+	}
+
+	data, mergeErr := mergeFiles(paths, config, o, func(path string) bool { return path != basePath })
+	if mergeErr != nil {
+		return mergeErr
+	}
+
+	return decodeConfig(data, basePath, config, o)
+}
+
+// envOverlayPath returns the path of the overlay file for env next to the
+// base configuration file.
+func envOverlayPath(basePath, env string) string {
+	ext := filepath.Ext(basePath)
+
+	return strings.TrimSuffix(basePath, ext) + "." + env + ext
+}
+
+// mergeFiles reads each file in turn and deep-merges it on top of the ones
+// before it, returning the merged document as YAML. Files for which optional
+// returns true are skipped when they do not exist. With WithValidateEachFile
+// every file is first loaded on its own into a new value of config's type.
+func mergeFiles(paths []string, config interface{}, o *options, optional func(path string) bool) ([]byte, error) {
+	var merged *yaml.Node
+
+	for _, path := range paths {
+		data, fileErr := os.ReadFile(path)
+		if fileErr != nil {
+			if errors.Is(fileErr, fs.ErrNotExist) && optional(path) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to load config file: %w", fileErr)
+		}
+
+		o.logger(LoadEvent{Phase: PhaseOpened, Path: path, Message: "opened config file"})
+		o.addSource(path)
+
+		if o.validateEachFile {
+			if validateErr := validateLayer(data, path, config, o); validateErr != nil {
+				return nil, validateErr
+			}
+		}
+
+		var doc yaml.Node
+		if yamlUnmarshalErr := yaml.Unmarshal(data, &doc); yamlUnmarshalErr != nil {
+			return nil, fmt.Errorf("failed to decode config file %s: %w", path, yamlUnmarshalErr)
+		}
+
+		if merged == nil {
+			merged = &doc
+
+			continue
+		}
+
+		mergeNodes(merged, &doc)
+	}
+
+	if merged == nil || len(merged.Content) == 0 {
+		return nil, nil
+	}
+
+	return yaml.Marshal(merged)
+}
+
+// validateLayer loads the content of a single file being merged into a new
+// value of config's type, so it is validated as though it were the only file.
+// The throwaway value is never frozen and its warnings are not kept, since
+// they are reported again for the merged configuration.
+func validateLayer(data []byte, path string, config interface{}, o *options) error {
+	if configErr := checkConfigPointer(config); configErr != nil {
+		return configErr
+	}
+
+	single := *o
+	single.freeze = false
+	single.warnings = nil
+
+	if decodeErr := decodeConfig(data, path, reflect.New(reflect.TypeOf(config).Elem()).Interface(), &single); decodeErr != nil {
+		return fmt.Errorf("%s: %w", path, decodeErr)
+	}
+
+	return nil
+}
+
+// mergeNodes deep-merges src into dst. Mappings are merged key by key, any
+// other value in src replaces the one in dst.
+func mergeNodes(dst, src *yaml.Node) {
+	if dst.Kind == yaml.DocumentNode && src.Kind == yaml.DocumentNode {
+		if len(src.Content) == 0 {
+			return
+		}
+
+		if len(dst.Content) == 0 {
+			dst.Content = src.Content
+
+			return
+		}
+
+		mergeNodes(dst.Content[0], src.Content[0])
+
+		return
+	}
+
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		*dst = *src
+
+		return
+	}
+
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+
+		existing := -1
+
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				existing = j + 1
+			}
+		}
+
+		if existing < 0 {
+			dst.Content = append(dst.Content, key, value)
+
+			continue
+		}
+
+		mergeNodes(dst.Content[existing], value)
+	}
+}
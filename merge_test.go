@@ -0,0 +1,68 @@
+package yamlconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigEnv(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(basePath, []byte("server:\n  address: localhost\ndatabase:\n  user: dev\n  level: low\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.production.yaml"), []byte("server:\n  port: 443\ndatabase:\n  user: prod\n"), 0o600))
+
+	t.Run("Load Config Env Merges Overlay", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		require.NoError(t, yamlconfig.LoadConfigEnv(basePath, "production", &cfg))
+
+		require.Equal(t, "localhost", cfg.Server.Address)
+		require.Equal(t, 443, cfg.Server.Port)
+		require.Equal(t, "prod", cfg.Database.User)
+		require.Equal(t, "low", cfg.Database.Level)
+	})
+
+	t.Run("Load Config Env Missing Overlay", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		loadErr := yamlconfig.LoadConfigEnv(basePath, "staging", &cfg)
+
+		require.ErrorContains(t, loadErr, "server.port: missing required config item")
+	})
+
+	t.Run("Load Config Env Validates After Merge", func(t *testing.T) {
+		events := 0
+		logger := yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseValidated {
+				events++
+			}
+		})
+
+		cfg := TestConfigNested{}
+		require.NoError(t, yamlconfig.LoadConfigEnv(basePath, "production", &cfg, logger))
+		require.Equal(t, 1, events)
+	})
+
+	t.Run("Load Config Env Validate Each File", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		loadErr := yamlconfig.LoadConfigEnv(basePath, "production", &cfg, yamlconfig.WithValidateEachFile())
+		require.EqualError(t, loadErr, basePath+": failed to load the config: server.port: missing required config item")
+		require.Empty(t, cfg.Server.Address)
+
+		completeDir := t.TempDir()
+		completePath := filepath.Join(completeDir, "config.yaml")
+		require.NoError(t, os.WriteFile(completePath, []byte("server:\n  address: localhost\n  port: 80\ndatabase:\n  user: dev\n  level: low\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(completeDir, "config.production.yaml"), []byte("server:\n  address: prod\n  port: 443\ndatabase:\n  user: prod\n  level: high\n"), 0o600))
+
+		require.NoError(t, yamlconfig.LoadConfigEnv(completePath, "production", &cfg, yamlconfig.WithValidateEachFile()))
+		require.Equal(t, 443, cfg.Server.Port)
+		require.Equal(t, "high", cfg.Database.Level)
+	})
+
+	t.Run("Load Config Env Missing Base", func(t *testing.T) {
+		cfg := TestConfigNested{}
+		require.Error(t, yamlconfig.LoadConfigEnv(filepath.Join(dir, "missing.yaml"), "production", &cfg))
+	})
+}
@@ -0,0 +1,89 @@
+package yamlconfig
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+)
+
+// LoadOutcome classifies how an attempt to load a configuration ended.
+type LoadOutcome string
+
+const (
+	// OutcomeSuccess is recorded when the configuration loaded and passed
+	// validation.
+	OutcomeSuccess LoadOutcome = "success"
+	// OutcomeNotFound is recorded when the configuration file does not exist.
+	OutcomeNotFound LoadOutcome = "not_found"
+	// OutcomeDecodeError is recorded when the content could not be read or
+	// decoded, such as for YAML syntax errors and values of the wrong type.
+	OutcomeDecodeError LoadOutcome = "decode_error"
+	// OutcomeValidationError is recorded when the content decoded but the
+	// configuration is not valid, including failures of defaults, hooks and
+	// validators registered with WithValidator.
+	OutcomeValidationError LoadOutcome = "validation_error"
+)
+
+// MetricsSink receives the outcome of every load so it can be counted, for
+// example by a Prometheus counter labelled with the outcome. The path is that
+// of the configuration file, or "" when the content did not come from a file.
+type MetricsSink interface {
+	RecordLoad(path string, outcome LoadOutcome)
+}
+
+// nopMetrics is the MetricsSink used when none is registered.
+type nopMetrics struct{}
+
+// RecordLoad discards the outcome.
+func (nopMetrics) RecordLoad(string, LoadOutcome) {}
+
+// WithMetrics registers a sink that is told the outcome of each load. Cached
+// results returned by Loader.Load are not loads and are not recorded. By
+// default outcomes are discarded.
+//
+// Example:
+//
+//	type counter struct{ loads *prometheus.CounterVec }
+//
+//	func (c counter) RecordLoad(path string, outcome yamlconfig.LoadOutcome) {
+//	    c.loads.WithLabelValues(string(outcome)).Inc()
+//	}
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithMetrics(counter{loads}))
+func WithMetrics(sink MetricsSink) Option {
+	return func(o *options) {
+		if sink != nil {
+			o.metrics = sink
+		}
+	}
+}
+
+// loadOutcome classifies the error returned by a load.
+func loadOutcome(err error) LoadOutcome {
+	var (
+		multiErr      *MultiError
+		validationErr *ValidationError
+	)
+
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.As(err, &multiErr):
+		// Collect mode gathers values of the wrong type with validation
+		// errors, and unreadable fromfile files that must not be mistaken
+		// for a missing config file
+		for _, e := range multiErr.Errors {
+			if _, ok := e.(typeError); ok {
+				return OutcomeDecodeError
+			}
+		}
+
+		return OutcomeValidationError
+	case errors.Is(err, fs.ErrNotExist):
+		return OutcomeNotFound
+	case errors.As(err, &validationErr), strings.HasPrefix(err.Error(), "failed to load the config:"):
+		return OutcomeValidationError
+	}
+
+	return OutcomeDecodeError
+}
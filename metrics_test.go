@@ -0,0 +1,67 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestMetricsSink struct {
+	outcomes []yamlconfig.LoadOutcome
+}
+
+func (s *TestMetricsSink) RecordLoad(_ string, outcome yamlconfig.LoadOutcome) {
+	s.outcomes = append(s.outcomes, outcome)
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("Load Outcomes Recorded", func(t *testing.T) {
+		sink := &TestMetricsSink{}
+		metrics := yamlconfig.WithMetrics(sink)
+
+		require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, "string: test\n"), &TestConfigEmpty{}, metrics))
+		require.Error(t, yamlconfig.LoadConfig("nonexistent.yml", &TestConfigEmpty{}, metrics))
+		require.Error(t, yamlconfig.LoadConfig(writeTempConfig(t, "string: [\n"), &TestConfigEmpty{}, metrics))
+		require.Error(t, yamlconfig.LoadConfig(writeTempConfig(t, "int: 1\n"), &TestConfigEmpty{}, metrics))
+
+		require.Equal(t, []yamlconfig.LoadOutcome{
+			yamlconfig.OutcomeSuccess,
+			yamlconfig.OutcomeNotFound,
+			yamlconfig.OutcomeDecodeError,
+			yamlconfig.OutcomeValidationError,
+		}, sink.outcomes)
+	})
+
+	t.Run("Collected Errors Classified", func(t *testing.T) {
+		sink := &TestMetricsSink{}
+		opts := []yamlconfig.Option{yamlconfig.WithMetrics(sink), yamlconfig.WithErrorMode(yamlconfig.Collect)}
+
+		cfg := TestConfigStruct{}
+		require.Error(t, yamlconfig.LoadConfig(writeTempConfig(t, "string: test\nint: abc\n"), &cfg, opts...))
+
+		cfg = TestConfigStruct{}
+		require.Error(t, yamlconfig.LoadConfig(writeTempConfig(t, "string: test\n"), &cfg, opts...))
+
+		fromFile := TestConfigFromFile{}
+		require.Error(t, yamlconfig.LoadConfig(writeTempConfig(t, "database:\n  user: app\n  password_file: /nonexistent/secret\n"), &fromFile, opts...))
+
+		require.Equal(t, []yamlconfig.LoadOutcome{
+			yamlconfig.OutcomeDecodeError,
+			yamlconfig.OutcomeValidationError,
+			yamlconfig.OutcomeValidationError,
+		}, sink.outcomes)
+	})
+
+	t.Run("Validator Failure Is A Validation Error", func(t *testing.T) {
+		sink := &TestMetricsSink{}
+		validator := yamlconfig.WithValidator(func(interface{}) error {
+			return errors.New("config_hash does not match the config")
+		})
+
+		cfg := TestConfigEmpty{}
+		require.Error(t, yamlconfig.ValidateBytes([]byte("string: test\n"), &cfg, yamlconfig.WithMetrics(sink), validator))
+		require.Equal(t, []yamlconfig.LoadOutcome{yamlconfig.OutcomeValidationError}, sink.outcomes)
+	})
+}
@@ -0,0 +1,109 @@
+package yamlconfig
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultVersionKey is the top-level key holding the schema version that
+// selects the migrations to apply, unless WithVersionKey sets another.
+const defaultVersionKey = "version"
+
+// migration rewrites a document from one schema version to the next.
+type migration struct {
+	to string
+	fn func(*yaml.Node) error
+}
+
+// WithMigration registers fn to migrate documents from schema version
+// fromVersion to toVersion, so files written for an older schema still load
+// into the current struct. When a file's top-level version key holds a
+// version with a migration registered, the migration is applied to the
+// document's root node before decoding, the version key is set to
+// toVersion, and the migrations from that version follow in turn until none
+// is registered. Registering fromVersion again replaces the earlier
+// migration. Migrations only apply to the load they are passed to.
+//
+// Example:
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithMigration("1", "2", func(root *yaml.Node) error {
+//	    // version 2 renamed listen to address
+//	    for i := 0; i+1 < len(root.Content); i += 2 {
+//	        if root.Content[i].Value == "listen" {
+//	            root.Content[i].Value = "address"
+//	        }
+//	    }
+//
+//	    return nil
+//	}))
+func WithMigration(fromVersion, toVersion string, fn func(*yaml.Node) error) Option {
+	return func(o *options) {
+		if o.migrations == nil {
+			o.migrations = map[string]migration{}
+		}
+
+		o.migrations[fromVersion] = migration{to: toVersion, fn: fn}
+	}
+}
+
+// WithVersionKey sets the top-level key holding the schema version that
+// selects the migrations registered with WithMigration. It defaults to
+// "version".
+func WithVersionKey(key string) Option {
+	return func(o *options) {
+		o.versionKey = key
+	}
+}
+
+// migrateDocument applies the chain of migrations starting from the version
+// held under the document's version key. Documents without a version are
+// left as they are.
+func migrateDocument(doc *yaml.Node, migrations map[string]migration, versionKey string) error {
+	root := resolveNode(doc)
+
+	seen := map[string]bool{}
+
+	for {
+		version := mappingValue(root, versionKey)
+		if version == nil || version.Kind != yaml.ScalarNode {
+			return nil
+		}
+
+		from := version.Value
+
+		m, ok := migrations[from]
+		if !ok {
+			return nil
+		}
+
+		if seen[from] {
+			return fmt.Errorf("config migrations loop back to version %q", from)
+		}
+
+		seen[from] = true
+
+		if err := m.fn(root); err != nil {
+			return fmt.Errorf("failed to migrate config from version %s to %s: %w", from, m.to, err)
+		}
+
+		setVersion(root, versionKey, m.to)
+	}
+}
+
+// setVersion sets the version key of the mapping root to version, adding the
+// key if a migration removed it. A quoted version stays quoted.
+func setVersion(root *yaml.Node, versionKey, version string) {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: version}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == versionKey {
+			node.Style = root.Content[i+1].Style
+			root.Content[i+1] = node
+
+			return
+		}
+	}
+
+	root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: versionKey}, node)
+}
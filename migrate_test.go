@@ -0,0 +1,114 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type TestConfigMigrate struct {
+	Version string `yaml:"version"`
+	Address string `yaml:"address"`
+	Timeout string `yaml:"timeout"`
+}
+
+// renameKey renames the key from of the mapping root to to.
+func renameKey(root *yaml.Node, from, to string) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == from {
+			root.Content[i].Value = to
+		}
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	migrations := []yamlconfig.Option{
+		yamlconfig.WithMigration("migrate-1", "migrate-2", func(root *yaml.Node) error {
+			renameKey(root, "listen", "address")
+
+			return nil
+		}),
+		yamlconfig.WithMigration("migrate-2", "migrate-3", func(root *yaml.Node) error {
+			root.Content = append(root.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "timeout"},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "5s"})
+
+			return nil
+		}),
+		yamlconfig.WithMigration("broken-1", "broken-2", func(*yaml.Node) error {
+			return errors.New("listen must be set")
+		}),
+		yamlconfig.WithMigration("loop-1", "loop-2", func(*yaml.Node) error { return nil }),
+		yamlconfig.WithMigration("loop-2", "loop-1", func(*yaml.Node) error { return nil }),
+	}
+
+	t.Run("Applies Migration Chain", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: migrate-1\nlisten: :8080\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, migrations...))
+		require.Equal(t, TestConfigMigrate{Version: "migrate-3", Address: ":8080", Timeout: "5s"}, cfg)
+	})
+
+	t.Run("Starts From The File Version", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: migrate-2\naddress: :8080\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, migrations...))
+		require.Equal(t, TestConfigMigrate{Version: "migrate-3", Address: ":8080", Timeout: "5s"}, cfg)
+	})
+
+	t.Run("Current Version Is Untouched", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: migrate-3\naddress: :8080\ntimeout: 1s\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, migrations...))
+		require.Equal(t, "1s", cfg.Timeout)
+	})
+
+	t.Run("Only Applied To Loads Given The Option", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: migrate-1\naddress: :8080\ntimeout: 1s\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, TestConfigMigrate{Version: "migrate-1", Address: ":8080", Timeout: "1s"}, cfg)
+	})
+
+	t.Run("Custom Version Key", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: build-7\nschema: migrate-1\nlisten: :8080\n")
+
+		opts := append([]yamlconfig.Option{yamlconfig.WithVersionKey("schema")}, migrations...)
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, opts...))
+		require.Equal(t, TestConfigMigrate{Version: "build-7", Address: ":8080", Timeout: "5s"}, cfg)
+	})
+
+	t.Run("Other Version Key Ignored", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: migrate-1\naddress: :8080\ntimeout: 1s\n")
+
+		opts := append([]yamlconfig.Option{yamlconfig.WithVersionKey("schema")}, migrations...)
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, opts...))
+		require.Equal(t, TestConfigMigrate{Version: "migrate-1", Address: ":8080", Timeout: "1s"}, cfg)
+	})
+
+	t.Run("Migration Error", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: broken-1\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, migrations...),
+			"failed to decode config file: failed to migrate config from version broken-1 to broken-2: listen must be set")
+	})
+
+	t.Run("Migration Loop", func(t *testing.T) {
+		cfg := TestConfigMigrate{}
+		path := writeTempConfig(t, "version: loop-1\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, migrations...), "failed to decode config file: config migrations loop back to version \"loop-1\"")
+	})
+}
@@ -0,0 +1,201 @@
+package yamlconfig
+
+import "gopkg.in/yaml.v3"
+
+// Option configures the behaviour of the configuration loader. Options are
+// passed as trailing arguments to LoadConfig and its variants.
+type Option func(*options)
+
+// options holds the settings collected from the Option values passed to a
+// loader function.
+type options struct {
+	logger               func(event LoadEvent)
+	allowZeroNumbers     bool
+	secretAnchors        map[string]bool
+	errorMode            ErrorMode
+	resolvePaths         bool
+	firstKeyWins         bool
+	verboseErrors        bool
+	rejectDirectives     bool
+	decoderFuncs         []func(*yaml.Decoder)
+	coerceStrings        bool
+	decodeWarnings       bool
+	freeze               bool
+	valueBasedRequired   bool
+	optionalPointers     bool
+	lowerMapKeys         bool
+	requireEnv           bool
+	searchMergeAll       bool
+	configValidators     []func(config interface{}) error
+	metrics              MetricsSink
+	warnings             *[]Warning
+	template             bool
+	templateData         map[string]interface{}
+	validateEachFile     bool
+	expandEnv            bool
+	envOpen              string
+	envClose             string
+	resolvers            map[string]Resolver
+	relaxedRequired      map[string]bool
+	sources              *[]string
+	lintRules            []LintRule
+	strictTypes          bool
+	envListSeparator     string
+	envKeyValueSeparator string
+	migrations           map[string]migration
+	versionKey           string
+}
+
+// ErrorMode decides what happens when loading finds an error.
+type ErrorMode int
+
+const (
+	// FailFast stops at the first error found. This is the default.
+	FailFast ErrorMode = iota
+	// Collect carries on after an error, descending into every nested
+	// struct, and returns all errors found together in a MultiError. Values
+	// of the wrong type, invalid defaults, unreadable fromfile files and
+	// unresolvable paths are collected along with validation errors.
+	Collect
+)
+
+// newOptions applies the provided Option values on top of the defaults and
+// returns the resulting settings.
+func newOptions(opts []Option) *options {
+	o := &options{
+		logger:               func(LoadEvent) {},
+		metrics:              nopMetrics{},
+		envOpen:              defaultEnvOpen,
+		envClose:             defaultEnvClose,
+		envListSeparator:     defaultEnvListSeparator,
+		envKeyValueSeparator: defaultEnvKeyValueSeparator,
+		versionKey:           defaultVersionKey,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithLogger registers a function that receives a LoadEvent for each phase of
+// loading a configuration file. By default events are discarded.
+//
+// Example:
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+//	    log.Printf("config %s: %s", e.Phase, e.Message)
+//	}))
+func WithLogger(logger func(event LoadEvent)) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithAllowZeroNumbers makes validation accept zero as a set value for
+// integer, unsigned integer and floating point fields, so a required field can
+// legitimately be configured as 0 even when its key is absent or
+// WithValueBasedRequired is used.
+func WithAllowZeroNumbers() Option {
+	return func(o *options) {
+		o.allowZeroNumbers = true
+	}
+}
+
+// WithSecretAnchors marks YAML anchors whose values are secret. A Loader
+// records every place the anchors are defined or aliased in the document and
+// redacts all of them in Loader.DumpRedacted, so a secret reused through an
+// alias cannot leak through a field that is not tagged as secret.
+func WithSecretAnchors(names ...string) Option {
+	return func(o *options) {
+		if o.secretAnchors == nil {
+			o.secretAnchors = map[string]bool{}
+		}
+
+		for _, name := range names {
+			o.secretAnchors[name] = true
+		}
+	}
+}
+
+// WithErrorMode sets whether loading stops at the first error or collects
+// every error, from decoding, defaulting and validation, into a MultiError.
+// Syntax errors always stop loading, since nothing can be decoded after them.
+func WithErrorMode(mode ErrorMode) Option {
+	return func(o *options) {
+		o.errorMode = mode
+	}
+}
+
+// WithValueBasedRequired judges required fields by their value rather than by
+// whether their key is present in the document. A required field holding the
+// zero value, such as "" or 0, is then reported as missing even when its key is
+// written out, and the rules of fields holding the zero value are not checked.
+// This was the behaviour before presence tracking and is kept for
+// compatibility.
+func WithValueBasedRequired() Option {
+	return func(o *options) {
+		o.valueBasedRequired = true
+	}
+}
+
+// WithRelaxedRequired makes the config items at the listed dotted paths, such
+// as "server.tls", optional as though they were tagged yamlconfig:"omitempty",
+// so one struct can be loaded strictly in production and leniently in
+// development by passing a different list. Items not listed stay required.
+// Relaxing an item never makes an omitempty item required, and values that
+// are set are still checked against their rules. A relaxed struct that is
+// absent is skipped entirely, but one that is present has its own required
+// fields checked.
+func WithRelaxedRequired(paths []string) Option {
+	return func(o *options) {
+		if o.relaxedRequired == nil {
+			o.relaxedRequired = map[string]bool{}
+		}
+
+		for _, path := range paths {
+			o.relaxedRequired[path] = true
+		}
+	}
+}
+
+// WithOptionalPointers treats every pointer field as optional, as though it
+// were tagged yamlconfig:"omitempty", so a nil pointer means the item was not
+// provided rather than that it is missing. Fields of other types stay
+// required. The struct a non-nil pointer points to is validated as usual, so
+// an optional section is checked in full whenever it is present.
+func WithOptionalPointers() Option {
+	return func(o *options) {
+		o.optionalPointers = true
+	}
+}
+
+// WithVerboseErrors attaches the offending value to every validation error,
+// for example "port=70000: value 70000 exceeds max=65535", to make it easier
+// to find which input caused the error. Values of fields tagged
+// yamlconfig:"secret" are shown as REDACTED.
+func WithVerboseErrors() Option {
+	return func(o *options) {
+		o.verboseErrors = true
+	}
+}
+
+// WithDecoder registers a function that is called with the yaml.v3 decoder
+// before the configuration is decoded, to change decoder settings that have
+// no option of their own, such as KnownFields.
+//
+// Example:
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithDecoder(func(d *yaml.Decoder) {
+//	    d.KnownFields(true)
+//	}))
+func WithDecoder(fn func(d *yaml.Decoder)) Option {
+	return func(o *options) {
+		if fn != nil {
+			o.decoderFuncs = append(o.decoderFuncs, fn)
+		}
+	}
+}
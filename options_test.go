@@ -0,0 +1,148 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type TestConfigNumbers struct {
+	Retries int     `yaml:"retries"`
+	Ratio   float64 `yaml:"ratio"`
+	Port    uint    `yaml:"port"`
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("Zero Numbers Are Set When Present", func(t *testing.T) {
+		cfg := TestConfigNumbers{}
+		path := writeTempConfig(t, "retries: 0\nratio: 0.0\nport: 0\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Zero Numbers Are Empty With Value Based Required", func(t *testing.T) {
+		cfg := TestConfigNumbers{}
+		path := writeTempConfig(t, "retries: 0\nratio: 0.0\nport: 0\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithValueBasedRequired()), "retries: missing required config item")
+	})
+
+	t.Run("Absent Zero Numbers Are Missing", func(t *testing.T) {
+		cfg := TestConfigNumbers{}
+		path := writeTempConfig(t, "retries: 0\nratio: 0.0\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "port: missing required config item")
+	})
+
+	t.Run("With Allow Zero Numbers", func(t *testing.T) {
+		cfg := TestConfigNumbers{}
+		path := writeTempConfig(t, "retries: 0\nratio: 0.0\nport: 0\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithAllowZeroNumbers(), yamlconfig.WithValueBasedRequired()))
+
+		cfg = TestConfigNumbers{}
+		path = writeTempConfig(t, "{}\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithAllowZeroNumbers()))
+	})
+
+	t.Run("Present Empty Values Are Checked By Rules", func(t *testing.T) {
+		cfg := TestConfigNotBlank{}
+		path := writeTempConfig(t, "name: \"\"\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "name: value must not be blank")
+	})
+
+	t.Run("With Decoder", func(t *testing.T) {
+		cfg := TestConfigNumbers{}
+		path := writeTempConfig(t, "retries: 1\nratio: 0.5\nport: 80\nunknown: true\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg = TestConfigNumbers{}
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithDecoder(func(d *yaml.Decoder) {
+			d.KnownFields(true)
+		}))
+		require.ErrorContains(t, loadConfigErr, "field unknown not found")
+	})
+
+	t.Run("With Decode Warnings", func(t *testing.T) {
+		path := writeTempConfig(t, "retries: 1\nratio: 0.5\nport: 80\nunknown: true\n")
+		knownFields := yamlconfig.WithDecoder(func(d *yaml.Decoder) {
+			d.KnownFields(true)
+		})
+
+		var warnings []string
+		logger := yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseWarning {
+				warnings = append(warnings, e.Message)
+			}
+		})
+
+		cfg := TestConfigNumbers{}
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, knownFields, logger, yamlconfig.WithDecodeWarnings()))
+		require.Equal(t, 80, int(cfg.Port))
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0], "line 4: field unknown not found")
+
+		path = writeTempConfig(t, "retries: many\nratio: 0.5\nport: 80\nunknown: true\n")
+		cfg = TestConfigNumbers{}
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, knownFields, yamlconfig.WithDecodeWarnings())
+		require.ErrorContains(t, loadConfigErr, "cannot unmarshal !!str `many` into int")
+		require.NotContains(t, loadConfigErr.Error(), "unknown")
+	})
+
+	t.Run("Optional Pointers", func(t *testing.T) {
+		type tls struct {
+			Cert string `yaml:"cert"`
+		}
+
+		type config struct {
+			Name    string `yaml:"name"`
+			TLS     *tls   `yaml:"tls"`
+			Retries *int   `yaml:"retries"`
+		}
+
+		cfg := config{}
+		path := writeTempConfig(t, "name: app\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: tls: missing required config item; retries: missing required config item")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithOptionalPointers()))
+		require.Nil(t, cfg.TLS)
+
+		path = writeTempConfig(t, "name: app\ntls: {}\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithOptionalPointers()),
+			"failed to load the config: tls.cert: missing required config item")
+	})
+
+	t.Run("Relaxed Required", func(t *testing.T) {
+		type config struct {
+			Name string `yaml:"name"`
+			Port int    `yaml:"port" yamlconfig:"max=65535"`
+			TLS  struct {
+				Cert string `yaml:"cert"`
+				Key  string `yaml:"key"`
+			} `yaml:"tls"`
+		}
+
+		relaxed := yamlconfig.WithRelaxedRequired([]string{"tls", "port"})
+		collect := yamlconfig.WithErrorMode(yamlconfig.Collect)
+
+		cfg := config{}
+		path := writeTempConfig(t, "name: app\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, collect),
+			"failed to load the config: port: missing required config item; tls: missing required config item")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, relaxed))
+
+		cfg = config{}
+		path = writeTempConfig(t, "port: 70000\ntls:\n  cert: a\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, relaxed, collect),
+			"failed to load the config: name: missing required config item; port: value 70000 exceeds max=65535; tls.key: missing required config item")
+
+		cfg = config{}
+		path = writeTempConfig(t, "name: app\ntls:\n  cert: a\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithRelaxedRequired([]string{"tls.key", "port"})))
+	})
+}
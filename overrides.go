@@ -0,0 +1,227 @@
+package yamlconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errUnknownPath is returned by setPath when the path does not lead to a
+// config item.
+var errUnknownPath = errors.New("unknown config item")
+
+// ApplyOverrides sets config items of an already loaded configuration from
+// their string form, as given by command line flags such as
+// --set server.port=9090, then validates the configuration again with the
+// validation settings of opts, as ApplyPatch does. Each key is a dotted path
+// of YAML key names, with bracketed indices for slices, and each value is
+// parsed into the type of the field it names. Nil struct pointers and maps
+// along the path are allocated. Use Loader.ApplyOverrides to judge required
+// fields by the keys present in the file and the overrides.
+//
+// Parameters:
+//
+// config: A pointer to the struct or map holding the current configuration.
+// overrides: The values to set, keyed by their dotted config path.
+// opts: The options the configuration was loaded with.
+//
+// Returns:
+// error: An error if a path does not name a config item, a value cannot be
+// parsed into its field, or the configuration is left invalid. The
+// configuration is not restored when an error is returned.
+//
+// Example:
+//
+// err := yamlconfig.ApplyOverrides(&cfg, map[string]string{"server.port": "9090"})
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func ApplyOverrides(config interface{}, overrides map[string]string, opts ...Option) error {
+	_, err := applyOverrides(config, overrides, newOptions(opts), nil)
+
+	return err
+}
+
+// ApplyOverrides sets config items of a configuration loaded by the Loader, in
+// the same way as the package level ApplyOverrides, and validates the result
+// as Loader.ApplyPatch does. The overridden items are only remembered as
+// present once the result is valid.
+func (l *Loader) ApplyOverrides(config interface{}, overrides map[string]string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	doc, err := applyOverrides(config, overrides, l.opts, l.presence(config))
+	if err != nil {
+		return err
+	}
+
+	l.patched = doc
+
+	return nil
+}
+
+// applyOverrides does the work of ApplyOverrides. When loaded is the document
+// the configuration was loaded from, the overridden items are marked in a copy
+// of it that is used to judge required fields and returned once the result is
+// valid.
+func applyOverrides(config interface{}, overrides map[string]string, o *options, loaded *yaml.Node) (*yaml.Node, error) {
+	if configErr := checkConfigPointer(config); configErr != nil {
+		return nil, configErr
+	}
+
+	// Apply the overrides in path order so errors are reported consistently
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	var presence *yaml.Node
+	if loaded != nil {
+		presence = copyNode(loaded)
+	}
+
+	for _, path := range paths {
+		segments, ok := parsePath(path)
+		if !ok {
+			return nil, fmt.Errorf("failed to apply config overrides: invalid config path: %s", path)
+		}
+
+		if err := setPath(reflect.ValueOf(config).Elem(), segments, overrides[path]); err != nil {
+			if errors.Is(err, errUnknownPath) {
+				return nil, fmt.Errorf("failed to apply config overrides: %w: %s", err, path)
+			}
+
+			return nil, fmt.Errorf("failed to apply config overrides: invalid value for %s: %w", path, err)
+		}
+
+		// Count the overridden item as present along with the keys of the
+		// document the configuration was loaded from
+		if presence != nil {
+			markPresent(presence, segments, overrides[path])
+		}
+	}
+
+	// Validate the overridden configuration
+	if validateConfigErr := revalidate(config, o, presence); validateConfigErr != nil {
+		return nil, fmt.Errorf("failed to apply config overrides: %w", validateConfigErr)
+	}
+
+	return presence, nil
+}
+
+// setPath follows the segments from val and stores value in the item reached.
+// Map values cannot be changed in place, so a settable copy is updated and
+// stored back into the map.
+func setPath(val reflect.Value, segments []pathSegment, value string) error {
+	if len(segments) == 0 {
+		return setStringValue(val, value)
+	}
+
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+
+		val = val.Elem()
+	}
+
+	segment := segments[0]
+
+	switch val.Kind() { //nolint:exhaustive // Only containers can be stepped into
+	case reflect.Struct:
+		if segment.isIndex {
+			return errUnknownPath
+		}
+
+		field, ok := fieldByKey(val, segment.key)
+		if !ok {
+			return errUnknownPath
+		}
+
+		return setPath(field, segments[1:], value)
+	case reflect.Map:
+		if segment.isIndex || val.Type().Key().Kind() != reflect.String {
+			return errUnknownPath
+		}
+
+		if val.IsNil() {
+			val.Set(reflect.MakeMap(val.Type()))
+		}
+
+		key := reflect.ValueOf(segment.key).Convert(val.Type().Key())
+
+		entry := reflect.New(val.Type().Elem()).Elem()
+		if existing := val.MapIndex(key); existing.IsValid() {
+			entry.Set(existing)
+		}
+
+		if err := setPath(entry, segments[1:], value); err != nil {
+			return err
+		}
+
+		val.SetMapIndex(key, entry)
+
+		return nil
+	case reflect.Slice, reflect.Array:
+		if !segment.isIndex || segment.index >= val.Len() {
+			return errUnknownPath
+		}
+
+		return setPath(val.Index(segment.index), segments[1:], value)
+	}
+
+	return errUnknownPath
+}
+
+// markPresent adds the keys along the segments to the document, so the config
+// item they lead to counts as present, with value as the item's value. Keys
+// already in the document are kept as they are. Marking stops at the first
+// index, since the sequence holding the element is already present.
+func markPresent(doc *yaml.Node, segments []pathSegment, value string) {
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+
+	node := doc.Content[0]
+
+	for i, segment := range segments {
+		if segment.isIndex {
+			return
+		}
+
+		// Aliases are replaced by a copy of their anchor so the anchor, which
+		// may be shared with the original document, is left untouched
+		if node.Kind == yaml.AliasNode {
+			*node = *copyNode(node.Alias)
+		}
+
+		if node.Kind != yaml.MappingNode {
+			*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		var next *yaml.Node
+
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == segment.key {
+				next = node.Content[j+1]
+			}
+		}
+
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if i == len(segments)-1 {
+				next = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+			}
+
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: segment.key}, next)
+		}
+
+		node = next
+	}
+}
@@ -0,0 +1,114 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigOverrides struct {
+	Name    string            `yaml:"name"`
+	Port    int               `yaml:"port" yamlconfig:"max=65535"`
+	Debug   bool              `yaml:"debug" yamlconfig:"omitempty"`
+	Hosts   []string          `yaml:"hosts"`
+	Labels  map[string]string `yaml:"labels" yamlconfig:"omitempty"`
+	Limits  *TestConfigEmpty  `yaml:"limits" yamlconfig:"omitempty"`
+	Servers map[string]struct {
+		Port int `yaml:"port"`
+	} `yaml:"servers" yamlconfig:"omitempty"`
+}
+
+func TestApplyOverrides(t *testing.T) {
+	load := func(t *testing.T) TestConfigOverrides {
+		t.Helper()
+
+		cfg := TestConfigOverrides{}
+		path := writeTempConfig(t, "name: app\nport: 80\nhosts:\n  - a\n  - b\nservers:\n  api:\n    port: 81\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		return cfg
+	}
+
+	t.Run("Values Parsed Into Field Types", func(t *testing.T) {
+		cfg := load(t)
+
+		require.NoError(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{
+			"name":             "8080",
+			"port":             "9090",
+			"debug":            "true",
+			"hosts[1]":         "c",
+			"labels.team":      "core",
+			"limits.string":    "x",
+			"servers.api.port": "82",
+			"servers.web.port": "83",
+		}))
+		require.Equal(t, "8080", cfg.Name)
+		require.Equal(t, 9090, cfg.Port)
+		require.True(t, cfg.Debug)
+		require.Equal(t, []string{"a", "c"}, cfg.Hosts)
+		require.Equal(t, map[string]string{"team": "core"}, cfg.Labels)
+		require.Equal(t, "x", cfg.Limits.String)
+		require.Equal(t, 82, cfg.Servers["api"].Port)
+		require.Equal(t, 83, cfg.Servers["web"].Port)
+	})
+
+	t.Run("Unknown Path", func(t *testing.T) {
+		cfg := load(t)
+
+		require.EqualError(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{"server.port": "1"}),
+			"failed to apply config overrides: unknown config item: server.port")
+		require.EqualError(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{"hosts[5]": "d"}),
+			"failed to apply config overrides: unknown config item: hosts[5]")
+		require.EqualError(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{"hosts[": "d"}),
+			"failed to apply config overrides: invalid config path: hosts[")
+	})
+
+	t.Run("Invalid Value", func(t *testing.T) {
+		cfg := load(t)
+
+		overrideErr := yamlconfig.ApplyOverrides(&cfg, map[string]string{"port": "high"})
+		require.ErrorContains(t, overrideErr, "failed to apply config overrides: invalid value for port: ")
+		require.ErrorContains(t, overrideErr, "cannot unmarshal !!str `high` into int")
+	})
+
+	t.Run("Result Validated", func(t *testing.T) {
+		cfg := load(t)
+
+		require.EqualError(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{"port": "70000"}),
+			"failed to apply config overrides: port: value 70000 exceeds max=65535")
+	})
+
+	t.Run("Present Zero Values Kept", func(t *testing.T) {
+		cfg := TestConfigOverrides{}
+		loader := yamlconfig.NewLoader(writeTempConfig(t, "name: \"\"\nport: 0\nhosts: []\n"))
+		require.NoError(t, loader.Load(&cfg))
+
+		require.NoError(t, loader.ApplyOverrides(&cfg, map[string]string{"debug": "true"}))
+		require.True(t, cfg.Debug)
+	})
+
+	t.Run("Overridden Zero Values Present", func(t *testing.T) {
+		cfg := TestConfigOverrides{}
+		loader := yamlconfig.NewLoader(writeTempConfig(t, "name: app\nport: 80\nhosts:\n  - a\n"))
+		require.NoError(t, loader.Load(&cfg))
+
+		require.NoError(t, loader.ApplyOverrides(&cfg, map[string]string{
+			"port":             "0",
+			"limits.string":    "",
+			"servers.api.port": "0",
+		}))
+		require.Equal(t, 0, cfg.Port)
+		require.Equal(t, 0, cfg.Servers["api"].Port)
+		require.Empty(t, cfg.Limits.String)
+		require.NoError(t, loader.ApplyOverrides(&cfg, map[string]string{"debug": "true"}))
+	})
+
+	t.Run("Load Options Applied", func(t *testing.T) {
+		cfg := TestConfigOverrides{}
+		require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, "name: app\nport: 80\nhosts:\n  - a\n"), &cfg))
+
+		require.Error(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{"port": "0"}))
+		require.NoError(t, yamlconfig.ApplyOverrides(&cfg, map[string]string{"port": "0"}, yamlconfig.WithAllowZeroNumbers()))
+	})
+}
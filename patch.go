@@ -0,0 +1,148 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ApplyPatch applies a YAML patch document on top of an already loaded
+// configuration struct. Only the keys present in the patch are changed, nested
+// structs are patched field by field, and the result is validated again with
+// the validation settings of opts, which should be those the configuration was
+// loaded with. The document it was loaded from is not known, so a required
+// field is missing whenever it is empty. Use Loader.ApplyPatch to judge
+// required fields by the keys present in the file and the patch instead.
+//
+// Parameters:
+//
+// config: A pointer to the struct holding the current configuration.
+// patch: The YAML document containing the values to override.
+// opts: The options the configuration was loaded with.
+//
+// Returns:
+// error: An error if the patch could not be decoded, references a key that
+// does not exist in the struct, or leaves the configuration invalid. The
+// configuration is not restored when an error is returned.
+//
+// Example:
+//
+// err := yamlconfig.ApplyPatch(&cfg, []byte("server:\n  port: 9090\n"))
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func ApplyPatch(config interface{}, patch []byte, opts ...Option) error {
+	_, err := applyPatch(config, patch, newOptions(opts), nil)
+
+	return err
+}
+
+// ApplyPatch applies a YAML patch document on top of a configuration loaded by
+// the Loader, in the same way as the package level ApplyPatch, and validates
+// the result with the Loader's options. A key present in the file of the last
+// successful load, or in a patch or override the Loader has applied since,
+// counts as set even if it holds the zero value. The keys of the patch are
+// only remembered once the result is valid.
+func (l *Loader) ApplyPatch(config interface{}, patch []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	doc, err := applyPatch(config, patch, l.opts, l.presence(config))
+	if err != nil {
+		return err
+	}
+
+	l.patched = doc
+
+	return nil
+}
+
+// applyPatch does the work of ApplyPatch. When loaded is the document the
+// configuration was loaded from, the keys of the patch are merged into a copy
+// of it that is used to judge required fields and returned once the result is
+// valid.
+func applyPatch(config interface{}, patch []byte, o *options, loaded *yaml.Node) (*yaml.Node, error) {
+	val := reflect.ValueOf(config)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a pointer to a struct, please ensure the input is a struct pointer")
+	}
+
+	// Parse the patch into a node tree so we know which keys were provided
+	var doc yaml.Node
+	if yamlUnmarshalErr := yaml.Unmarshal(patch, &doc); yamlUnmarshalErr != nil {
+		return nil, fmt.Errorf("failed to decode config patch: %w", yamlUnmarshalErr)
+	}
+
+	if len(doc.Content) > 0 {
+		if patchErr := patchStruct(val.Elem(), doc.Content[0], ""); patchErr != nil {
+			return nil, fmt.Errorf("failed to apply config patch: %w", patchErr)
+		}
+	}
+
+	// Count the keys of the patch as present along with those of the document
+	// the configuration was loaded from
+	var presence *yaml.Node
+	if loaded != nil {
+		presence = copyNode(loaded)
+		mergeNodes(presence, &doc)
+	}
+
+	// Validate the patched configuration
+	if validateConfigErr := revalidate(config, o, presence); validateConfigErr != nil {
+		return nil, fmt.Errorf("failed to apply config patch: %w", validateConfigErr)
+	}
+
+	return presence, nil
+}
+
+// patchStruct sets the fields of val named by the keys of the mapping node,
+// recursing into nested structs so that sibling fields are left untouched.
+func patchStruct(val reflect.Value, node *yaml.Node, prefix string) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("expected a mapping at %q", prefix)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		path := joinPath(prefix, key)
+
+		field, ok := fieldByKey(val, key)
+		if !ok {
+			return fmt.Errorf("unknown config item: %s", path)
+		}
+
+		// Allocate nil struct pointers so they can be patched in place
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct && value.Kind == yaml.MappingNode {
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Struct && value.Kind == yaml.MappingNode {
+			if err := patchStruct(field, value, path); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := value.Decode(field.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// joinPath appends key to the dotted path prefix.
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
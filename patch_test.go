@@ -0,0 +1,100 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigPatchZero struct {
+	Name    string `yaml:"name"`
+	Enabled bool   `yaml:"enabled"`
+	Retries int    `yaml:"retries" yamlconfig:"max=5"`
+	Region  string `yaml:"region" yamlconfig:"default=eu"`
+}
+
+func TestApplyPatch(t *testing.T) {
+	load := func(t *testing.T) TestConfigStruct {
+		t.Helper()
+
+		cfg := TestConfigStruct{}
+		path := writeTempConfig(t, "string: test\nint: 1\nbool: true\nslice:\n  - foo\nunit: 1\nfloat: 1.0\nstruct:\n  string: test\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		return cfg
+	}
+
+	t.Run("Apply Patch Overrides Only Present Fields", func(t *testing.T) {
+		cfg := load(t)
+
+		patchErr := yamlconfig.ApplyPatch(&cfg, []byte("int: 5\nstruct:\n  string: patched\n"))
+		require.NoError(t, patchErr)
+
+		require.Equal(t, 5, cfg.Int)
+		require.Equal(t, "patched", cfg.Struct.String)
+		require.Equal(t, "test", cfg.String)
+		require.Equal(t, []string{"foo"}, cfg.Slice)
+	})
+
+	t.Run("Apply Patch Unknown Key", func(t *testing.T) {
+		cfg := load(t)
+
+		patchErr := yamlconfig.ApplyPatch(&cfg, []byte("struct:\n  missing: 1\n"))
+		require.ErrorContains(t, patchErr, "struct.missing")
+	})
+
+	t.Run("Apply Patch Invalid Result", func(t *testing.T) {
+		cfg := load(t)
+
+		patchErr := yamlconfig.ApplyPatch(&cfg, []byte("string: \"\"\n"))
+		require.Error(t, patchErr)
+	})
+
+	t.Run("Apply Patch Type Mismatch", func(t *testing.T) {
+		cfg := load(t)
+
+		patchErr := yamlconfig.ApplyPatch(&cfg, []byte("int: abc\n"))
+		require.Error(t, patchErr)
+	})
+
+	t.Run("Apply Patch Keeps Present Zero Values", func(t *testing.T) {
+		cfg := TestConfigPatchZero{}
+		loader := yamlconfig.NewLoader(writeTempConfig(t, "name: app\nenabled: false\nretries: 0\n"))
+		require.NoError(t, loader.Load(&cfg))
+
+		require.NoError(t, loader.ApplyPatch(&cfg, []byte("name: patched\n")))
+		require.Equal(t, "patched", cfg.Name)
+		require.ErrorContains(t, loader.ApplyPatch(&cfg, []byte("retries: 9\n")), "retries: value 9 exceeds max=5")
+	})
+
+	t.Run("Apply Patch Writes Zero Value", func(t *testing.T) {
+		cfg := TestConfigPatchZero{}
+		loader := yamlconfig.NewLoader(writeTempConfig(t, "name: app\nenabled: true\nretries: 1\n"))
+		require.NoError(t, loader.Load(&cfg))
+
+		require.NoError(t, loader.ApplyPatch(&cfg, []byte("enabled: false\nretries: 0\nregion: \"\"\n")))
+		require.False(t, cfg.Enabled)
+		require.Equal(t, 0, cfg.Retries)
+		require.NoError(t, loader.ApplyPatch(&cfg, []byte("name: patched\n")))
+	})
+
+	t.Run("Apply Patch Keeps Keys Only Once Valid", func(t *testing.T) {
+		cfg := TestConfigPatchZero{}
+		loader := yamlconfig.NewLoader(writeTempConfig(t, "name: app\nenabled: true\nretries: 1\n"))
+		require.NoError(t, loader.Load(&cfg))
+
+		require.Error(t, loader.ApplyPatch(&cfg, []byte("region: \"\"\nretries: 9\n")))
+		require.EqualError(t, loader.ApplyPatch(&cfg, []byte("retries: 2\n")),
+			"failed to apply config patch: region: missing required config item")
+	})
+
+	t.Run("Apply Patch Uses Load Options", func(t *testing.T) {
+		cfg := TestConfigPatchZero{}
+		path := writeTempConfig(t, "name: app\nenabled: true\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithAllowZeroNumbers()))
+
+		require.Error(t, yamlconfig.ApplyPatch(&cfg, []byte("name: patched\n")))
+		require.NoError(t, yamlconfig.ApplyPatch(&cfg, []byte("name: patched\n"), yamlconfig.WithAllowZeroNumbers()))
+	})
+}
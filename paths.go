@@ -0,0 +1,60 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+)
+
+// WithResolvePaths rewrites relative file paths held in fields tagged
+// yamlconfig:"path" to absolute paths, resolved against the directory of the
+// configuration file rather than the process working directory. Both string
+// and []string fields are supported.
+func WithResolvePaths() Option {
+	return func(o *options) {
+		o.resolvePaths = true
+	}
+}
+
+// resolvePaths makes every relative path in a path tagged field absolute,
+// treating it as relative to baseDir. Paths that cannot be resolved are
+// reported to errs.
+func resolvePaths(val reflect.Value, baseDir string, errs *errorList) error {
+	return walkFields(val, "", func(field reflect.Value, typ reflect.StructField, path string) error {
+		if !parseTag(typ.Tag.Get("yamlconfig")).has("path") {
+			return nil
+		}
+
+		switch {
+		case field.Kind() == reflect.String:
+			return errs.add(resolvePath(field, baseDir))
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+			for i := 0; i < field.Len(); i++ {
+				if err := errs.add(resolvePath(field.Index(i), baseDir)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		return errs.add(fmt.Errorf("path is only supported on string fields: %s", path))
+	})
+}
+
+// resolvePath rewrites a single string value to an absolute path.
+func resolvePath(field reflect.Value, baseDir string) error {
+	value := field.String()
+	if value == "" || filepath.IsAbs(value) {
+		return nil
+	}
+
+	abs, absErr := filepath.Abs(filepath.Join(baseDir, value))
+	if absErr != nil {
+		return fmt.Errorf("failed to resolve path %q: %w", value, absErr)
+	}
+
+	field.SetString(abs)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package yamlconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigPaths struct {
+	TLS struct {
+		Cert string `yaml:"cert" yamlconfig:"path"`
+		Key  string `yaml:"key" yamlconfig:"path"`
+	} `yaml:"tls"`
+	Includes []string `yaml:"includes" yamlconfig:"path"`
+}
+
+func TestResolvePaths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("tls:\n  cert: ./certs/server.pem\n  key: /etc/key.pem\nincludes:\n  - extra.yml\n"), 0o600))
+
+	t.Run("Resolve Paths Relative To Config File", func(t *testing.T) {
+		cfg := TestConfigPaths{}
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithResolvePaths()))
+
+		require.Equal(t, filepath.Join(dir, "certs", "server.pem"), cfg.TLS.Cert)
+		require.Equal(t, "/etc/key.pem", cfg.TLS.Key)
+		require.Equal(t, []string{filepath.Join(dir, "extra.yml")}, cfg.Includes)
+	})
+
+	t.Run("Paths Unchanged Without Option", func(t *testing.T) {
+		cfg := TestConfigPaths{}
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		require.Equal(t, "./certs/server.pem", cfg.TLS.Cert)
+	})
+}
@@ -0,0 +1,44 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// utf8BOM is the byte order mark some editors write at the start of UTF-8
+// files.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// stripBOM removes a leading UTF-8 byte order mark from data.
+func stripBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, utf8BOM)
+}
+
+// WithRejectDirectives fails loading when the file starts with YAML
+// directives such as "%YAML 1.2" or "%TAG", naming the line of the first one.
+// Directives are rarely intended in configuration files and an unsupported
+// version directive otherwise produces an unclear decode error.
+func WithRejectDirectives() Option {
+	return func(o *options) {
+		o.rejectDirectives = true
+	}
+}
+
+// checkDirectives returns an error naming the first directive line found
+// before the first document starts.
+func checkDirectives(data []byte) error {
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+
+		switch {
+		case bytes.HasPrefix(line, []byte("%")):
+			return fmt.Errorf("failed to decode config file: line %d: YAML directives are not allowed: %s", i+1, trimmed)
+		case len(trimmed) == 0 || trimmed[0] == '#':
+			continue
+		}
+
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreamble(t *testing.T) {
+	t.Run("Leading BOM Is Stripped", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "\xef\xbb\xbfstring: value\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "value", cfg.String)
+	})
+
+	t.Run("Leading BOM Before JSON", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "\xef\xbb\xbf{\"string\": \"value\",}")
+
+		require.ErrorContains(t, yamlconfig.LoadConfigAuto(path, &cfg), "failed to decode JSON config file")
+	})
+
+	t.Run("Directives Allowed By Default", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "%YAML 1.1\n---\nstring: value\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("With Reject Directives", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "# generated\n%YAML 1.2\n---\nstring: value\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithRejectDirectives()),
+			"failed to decode config file: line 2: YAML directives are not allowed: %YAML 1.2")
+
+		path = writeTempConfig(t, "---\nstring: value\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithRejectDirectives()))
+	})
+}
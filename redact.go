@@ -0,0 +1,166 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue replaces the value of secret config items in redacted output.
+const redactedValue = "REDACTED"
+
+// DumpRedacted marshals the configuration to YAML with the value of every
+// field tagged yamlconfig:"secret" replaced by "REDACTED". It is intended for
+// logging or displaying the configuration an application is running with.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+//
+// Returns:
+// []byte: The redacted YAML document.
+// error: An error if the configuration could not be marshalled.
+//
+// Example:
+//
+// out, err := yamlconfig.DumpRedacted(&cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func DumpRedacted(config interface{}) ([]byte, error) {
+	return dumpRedacted(config, nil)
+}
+
+// dumpRedacted marshals the configuration, redacting secret tagged fields and
+// any value found at one of the given document paths.
+func dumpRedacted(config interface{}, paths map[string]bool) ([]byte, error) {
+	var node yaml.Node
+	if encodeErr := node.Encode(config); encodeErr != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", encodeErr)
+	}
+
+	redactTagged(reflect.ValueOf(config), &node)
+	redactPaths(&node, "", paths)
+
+	var buf bytes.Buffer
+	if writeErr := writeNode(&buf, &node, newWriteOptions(nil)); writeErr != nil {
+		return nil, writeErr
+	}
+
+	return buf.Bytes(), nil
+}
+
+// redactTagged replaces the nodes of fields tagged yamlconfig:"secret" in the
+// node encoded from val, descending through pointers, interfaces, nested
+// structs, map values and slice and array elements.
+func redactTagged(val reflect.Value, node *yaml.Node) {
+	val = indirect(val)
+	node = resolveNode(node)
+
+	if !val.IsValid() || node == nil {
+		return
+	}
+
+	switch val.Kind() { //nolint:exhaustive // Only containers hold secret fields
+	case reflect.Struct:
+		if node.Kind == yaml.MappingNode {
+			redactFields(val, node)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		// Match each encoded key to its map entry by decoding it into the
+		// map's key type
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := reflect.New(val.Type().Key())
+			if decodeErr := node.Content[i].Decode(key.Interface()); decodeErr != nil {
+				continue
+			}
+
+			if value := val.MapIndex(key.Elem()); value.IsValid() {
+				redactTagged(value, node.Content[i+1])
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for i := 0; i < val.Len() && i < len(node.Content); i++ {
+			redactTagged(val.Index(i), node.Content[i])
+		}
+	}
+}
+
+// redactFields replaces the nodes of the secret tagged fields of the struct
+// val in the mapping node encoded from it, and redacts the other fields'
+// values in turn.
+func redactFields(val reflect.Value, node *yaml.Node) {
+	for i := 0; i < val.NumField(); i++ {
+		typ := val.Type().Field(i)
+
+		key, inline, skip := yamlKey(typ)
+		if skip {
+			continue
+		}
+
+		if inline {
+			redactTagged(val.Field(i), node)
+
+			continue
+		}
+
+		value := mappingValue(node, key)
+		if value == nil {
+			continue
+		}
+
+		if parseTag(typ.Tag.Get("yamlconfig")).has("secret") {
+			redactNode(value)
+
+			continue
+		}
+
+		redactTagged(val.Field(i), value)
+	}
+}
+
+// redactPaths replaces the nodes found at any of the given document paths.
+func redactPaths(node *yaml.Node, path string, paths map[string]bool) {
+	if len(paths) == 0 {
+		return
+	}
+
+	node = resolveNode(node)
+	if node == nil {
+		return
+	}
+
+	if path != "" && paths[path] {
+		redactNode(node)
+
+		return
+	}
+
+	switch node.Kind { //nolint:exhaustive // Only containers hold nested values
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			redactPaths(node.Content[i+1], joinPath(path, node.Content[i].Value), paths)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			redactPaths(item, path+"["+strconv.Itoa(i)+"]", paths)
+		}
+	}
+}
+
+// redactNode replaces the node in place with the redacted placeholder.
+func redactNode(node *yaml.Node) {
+	*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: redactedValue}
+}
@@ -0,0 +1,75 @@
+package yamlconfig_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigSecret struct {
+	Database struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password" yamlconfig:"secret"`
+	} `yaml:"database"`
+	Cache struct {
+		Password string `yaml:"password"`
+	} `yaml:"cache"`
+}
+
+type TestConfigSecretUser struct {
+	Name     string `yaml:"name"`
+	Password string `yaml:"password" yamlconfig:"secret"`
+}
+
+type TestConfigNestedSecrets struct {
+	Databases map[string]TestConfigSecretUser    `yaml:"databases"`
+	Users     []TestConfigSecretUser             `yaml:"users"`
+	Replicas  [1]*TestConfigSecretUser           `yaml:"replicas"`
+	Admin     *TestConfigSecretUser              `yaml:"admin"`
+	Backups   map[string][]*TestConfigSecretUser `yaml:"backups"`
+}
+
+func TestDumpRedacted(t *testing.T) {
+	t.Run("Dump Redacted Secret Fields", func(t *testing.T) {
+		cfg := TestConfigSecret{}
+		cfg.Database.User = "app"
+		cfg.Database.Password = "s3cret"
+		cfg.Cache.Password = "other"
+
+		out, dumpErr := yamlconfig.DumpRedacted(&cfg)
+		require.NoError(t, dumpErr)
+
+		require.Equal(t, "database:\n    user: app\n    password: REDACTED\ncache:\n    password: other\n", string(out))
+	})
+
+	t.Run("Dump Redacted Nested Secrets", func(t *testing.T) {
+		cfg := TestConfigNestedSecrets{
+			Databases: map[string]TestConfigSecretUser{"main": {Name: "app", Password: "hunter2"}},
+			Users:     []TestConfigSecretUser{{Name: "ops", Password: "s3cret"}},
+			Replicas:  [1]*TestConfigSecretUser{{Name: "replica", Password: "r3plica"}},
+			Admin:     &TestConfigSecretUser{Name: "root", Password: "rootpw"},
+			Backups:   map[string][]*TestConfigSecretUser{"nightly": {{Name: "backup", Password: "b4ckup"}}},
+		}
+
+		out, dumpErr := yamlconfig.DumpRedacted(&cfg)
+		require.NoError(t, dumpErr)
+
+		for _, secret := range []string{"hunter2", "s3cret", "r3plica", "rootpw", "b4ckup"} {
+			require.NotContains(t, string(out), secret)
+		}
+
+		require.Equal(t, 5, strings.Count(string(out), "password: REDACTED"))
+		require.Contains(t, string(out), "name: replica")
+	})
+
+	t.Run("Dump Redacted Map Root", func(t *testing.T) {
+		cfg := map[string]*TestConfigSecretUser{"root": {Name: "root", Password: "rootpw"}}
+
+		out, dumpErr := yamlconfig.DumpRedacted(cfg)
+		require.NoError(t, dumpErr)
+
+		require.Equal(t, "root:\n    name: root\n    password: REDACTED\n", string(out))
+	})
+}
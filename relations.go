@@ -0,0 +1,154 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// relation checks a field against sibling fields of the struct holding it,
+// using the argument given to the relation in the field's yamlconfig tag.
+// Unlike a rule, a relation is checked whether or not the field is empty,
+// using isSet to decide which fields, given with their YAML key, are set.
+type relation func(parent, field reflect.Value, key, arg string, isSet func(field reflect.Value, key string) bool) error
+
+// relations lists the relations that can be named in a yamlconfig tag, in the
+// order they are applied.
+var relations = []struct {
+	name  string
+	check relation
+}{
+	{"together", validateTogether},
+	{"lenof", validateLenOf},
+	{"refkey", validateRefKey},
+}
+
+// validateRelations applies the relations named in a field's yamlconfig tag.
+func validateRelations(parent, field reflect.Value, key string, tag fieldTag, isSet func(field reflect.Value, key string) bool) error {
+	for _, r := range relations {
+		if arg, ok := tag.get(r.name); ok {
+			if err := r.check(parent, field, key, arg, isSet); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// siblingField finds the field of the struct parent named either by its Go
+// field name or its YAML key, and returns it with its YAML key.
+func siblingField(parent reflect.Value, name string) (reflect.Value, string, bool) {
+	if field, ok := parent.Type().FieldByName(name); ok {
+		key, _, _ := yamlKey(field)
+
+		return parent.FieldByIndex(field.Index), key, true
+	}
+
+	if field, ok := fieldByKey(parent, name); ok {
+		return field, name, true
+	}
+
+	return reflect.Value{}, "", false
+}
+
+// validateTogether checks that the field and the space separated siblings are
+// either all set or all empty.
+func validateTogether(parent, field reflect.Value, fieldKey, arg string, isSet func(field reflect.Value, key string) bool) error {
+	fieldSet := isSet(field, fieldKey)
+
+	var mismatched []string
+
+	for _, name := range strings.Fields(arg) {
+		sibling, key, ok := siblingField(parent, name)
+		if !ok {
+			return fmt.Errorf("together refers to unknown config item %q", name)
+		}
+
+		if isSet(sibling, key) != fieldSet {
+			mismatched = append(mismatched, key)
+		}
+	}
+
+	if len(mismatched) == 0 {
+		return nil
+	}
+
+	if fieldSet {
+		return fmt.Errorf("is set but %s is not, they must be set together", strings.Join(mismatched, ", "))
+	}
+
+	return fmt.Errorf("is not set but %s is, they must be set together", strings.Join(mismatched, ", "))
+}
+
+// validateLenOf checks that the number of elements in the slice, array or map
+// field equals the value of the integer sibling named in the argument.
+func validateLenOf(parent, field reflect.Value, _, arg string, _ func(reflect.Value, string) bool) error {
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array && field.Kind() != reflect.Map {
+		return fmt.Errorf("lenof is only supported on slice, array and map fields")
+	}
+
+	sibling, key, ok := siblingField(parent, arg)
+	if !ok {
+		return fmt.Errorf("lenof refers to unknown config item %q", arg)
+	}
+
+	var expected int64
+
+	switch sibling.Kind() { //nolint:exhaustive // Only integers hold a length
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		expected = sibling.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		expected = int64(sibling.Uint())
+	default:
+		return fmt.Errorf("lenof refers to %s, which is not an integer", key)
+	}
+
+	if int64(field.Len()) != expected {
+		return fmt.Errorf("has %d elements but %s is %d, they must match", field.Len(), key, expected)
+	}
+
+	return nil
+}
+
+// validateRefKey checks that the value of the string field, or each element
+// of a slice of strings, is a key of the map sibling named in the argument,
+// so a reference to another section by name cannot dangle. Empty values are
+// not checked.
+func validateRefKey(parent, field reflect.Value, _, arg string, _ func(reflect.Value, string) bool) error {
+	sibling, key, ok := siblingField(parent, arg)
+	if !ok {
+		return fmt.Errorf("refkey refers to unknown config item %q", arg)
+	}
+
+	if mapType := derefType(sibling.Type()); mapType.Kind() != reflect.Map || mapType.Key().Kind() != reflect.String {
+		return fmt.Errorf("refkey refers to %s, which is not a map with string keys", key)
+	}
+
+	// A nil pointer to a map defines no keys
+	sibling = indirect(sibling)
+	isKey := func(name string) bool {
+		return sibling.IsValid() && sibling.MapIndex(reflect.ValueOf(name).Convert(sibling.Type().Key())).IsValid()
+	}
+
+	switch field.Kind() { //nolint:exhaustive // Only strings hold references
+	case reflect.String:
+		if name := field.String(); name != "" && !isKey(name) {
+			return fmt.Errorf("refers to %q, which is not defined in %s", name, key)
+		}
+	case reflect.Slice, reflect.Array:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("refkey is only supported on string fields and slices of strings")
+		}
+
+		for i := 0; i < field.Len(); i++ {
+			if name := field.Index(i).String(); !isKey(name) {
+				return fmt.Errorf("element %d refers to %q, which is not defined in %s", i, name, key)
+			}
+		}
+	default:
+		return fmt.Errorf("refkey is only supported on string fields and slices of strings")
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigTogether struct {
+	Name      string `yaml:"name"`
+	ProxyHost string `yaml:"proxy_host" yamlconfig:"omitempty,together=proxy_port"`
+	ProxyPort int    `yaml:"proxy_port" yamlconfig:"omitempty"`
+}
+
+type TestConfigLenOf struct {
+	ShardCount int      `yaml:"shard_count"`
+	Shards     []string `yaml:"shards" yamlconfig:"omitempty,lenof=ShardCount"`
+}
+
+type TestConfigRefKey struct {
+	DefaultBackend string              `yaml:"default_backend" yamlconfig:"refkey=Backends"`
+	Fallbacks      []string            `yaml:"fallbacks" yamlconfig:"omitempty,refkey=backends"`
+	Backends       map[string]struct{} `yaml:"backends"`
+}
+
+func TestRelations(t *testing.T) {
+	t.Run("Together All Or None", func(t *testing.T) {
+		for _, content := range []string{"name: app\n", "name: app\nproxy_host: proxy\nproxy_port: 3128\n"} {
+			cfg := TestConfigTogether{}
+			path := writeTempConfig(t, content)
+
+			require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		}
+	})
+
+	t.Run("Together Partially Set", func(t *testing.T) {
+		cfg := TestConfigTogether{}
+		path := writeTempConfig(t, "name: app\nproxy_host: proxy\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "proxy_host: is set but proxy_port is not, they must be set together")
+
+		cfg = TestConfigTogether{}
+		path = writeTempConfig(t, "name: app\nproxy_port: 3128\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "proxy_host: is not set but proxy_port is, they must be set together")
+	})
+
+	t.Run("Together With Zero Numbers", func(t *testing.T) {
+		cfg := TestConfigTogether{}
+		path := writeTempConfig(t, "name: app\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithAllowZeroNumbers()))
+
+		cfg = TestConfigTogether{}
+		path = writeTempConfig(t, "name: app\nproxy_port: 0\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithAllowZeroNumbers()),
+			"proxy_host: is not set but proxy_port is, they must be set together")
+	})
+
+	t.Run("Together Unknown Sibling", func(t *testing.T) {
+		cfg := struct {
+			Host string `yaml:"host" yamlconfig:"together=Missing"`
+		}{}
+		path := writeTempConfig(t, "host: a\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "unknown config item \"Missing\"")
+	})
+
+	t.Run("Length Of Sibling", func(t *testing.T) {
+		cfg := TestConfigLenOf{}
+		path := writeTempConfig(t, "shard_count: 2\nshards: [a, b]\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg = TestConfigLenOf{}
+		path = writeTempConfig(t, "shard_count: 3\nshards: [a, b]\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: shards: has 2 elements but shard_count is 3, they must match")
+
+		cfg = TestConfigLenOf{}
+		path = writeTempConfig(t, "shard_count: 1\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: shards: has 0 elements but shard_count is 1, they must match")
+	})
+
+	t.Run("Length Of Non-Integer Sibling", func(t *testing.T) {
+		cfg := struct {
+			Name  string   `yaml:"name"`
+			Hosts []string `yaml:"hosts" yamlconfig:"lenof=name"`
+		}{}
+		path := writeTempConfig(t, "name: a\nhosts: [a]\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "hosts: lenof refers to name, which is not an integer")
+	})
+
+	t.Run("Reference To Map Key", func(t *testing.T) {
+		cfg := TestConfigRefKey{}
+		path := writeTempConfig(t, "default_backend: primary\nfallbacks: [secondary]\nbackends:\n  primary: {}\n  secondary: {}\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg = TestConfigRefKey{}
+		path = writeTempConfig(t, "default_backend: primray\nfallbacks: [primary, tertiary]\nbackends:\n  primary: {}\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect)),
+			"failed to load the config: default_backend: refers to \"primray\", which is not defined in backends; "+
+				"fallbacks: element 1 refers to \"tertiary\", which is not defined in backends")
+	})
+
+	t.Run("Reference To Non-Map Sibling", func(t *testing.T) {
+		cfg := struct {
+			Name    string `yaml:"name"`
+			Default string `yaml:"default" yamlconfig:"refkey=name"`
+		}{}
+		path := writeTempConfig(t, "name: a\ndefault: a\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "default: refkey refers to name, which is not a map with string keys")
+	})
+}
@@ -0,0 +1,70 @@
+package yamlconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// RestartRequiredError is returned by Store.Reload when the new configuration
+// changes fields that are not tagged yamlconfig:"reloadable". Such changes
+// only take effect once the program restarts.
+type RestartRequiredError struct {
+	// Paths holds the dotted paths of the changed fields, in field order.
+	Paths []string
+}
+
+// Error implements the error interface.
+func (e *RestartRequiredError) Error() string {
+	return "config items require a restart to change: " + strings.Join(e.Paths, ", ")
+}
+
+// hasReloadableFields reports whether typ, or any struct reachable from it,
+// has a field tagged yamlconfig:"reloadable". The seen map guards against
+// recursive types.
+func hasReloadableFields(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	typ = derefType(typ)
+	if seen[typ] || typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if parseTag(field.Tag.Get("yamlconfig")).has("reloadable") || hasReloadableFields(field.Type, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restartRequiredPaths returns the paths of the fields that differ between
+// the structs old and updated and are not tagged yamlconfig:"reloadable". A
+// reloadable struct makes every field within it reloadable.
+func restartRequiredPaths(old, updated reflect.Value, path string) []string {
+	var paths []string
+
+	for i := 0; i < old.NumField(); i++ {
+		key, inline, skip := yamlKey(old.Type().Field(i))
+		if skip || parseTag(old.Type().Field(i).Tag.Get("yamlconfig")).has("reloadable") {
+			continue
+		}
+
+		fieldPath := path
+		if !inline {
+			fieldPath = joinPath(path, key)
+		}
+
+		oldField, updatedField := indirect(old.Field(i)), indirect(updated.Field(i))
+
+		switch {
+		case oldField.IsValid() && updatedField.IsValid() && oldField.Kind() == reflect.Struct:
+			paths = append(paths, restartRequiredPaths(oldField, updatedField, fieldPath)...)
+		case !reflect.DeepEqual(old.Field(i).Interface(), updated.Field(i).Interface()):
+			paths = append(paths, fieldPath)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,84 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Resolver looks up values held outside the configuration file, such as
+// secrets in a key-value store. It is registered for a scheme with
+// WithResolver and given each reference using that scheme.
+type Resolver interface {
+	// Resolve returns the value that ref refers to. The ref is the whole
+	// value written in the file, scheme included, such as kv://secrets/db.
+	Resolve(ref string) (string, error)
+}
+
+// ResolverFunc adapts an ordinary function to the Resolver interface.
+type ResolverFunc func(ref string) (string, error)
+
+// Resolve calls f(ref).
+func (f ResolverFunc) Resolve(ref string) (string, error) {
+	return f(ref)
+}
+
+// WithResolver replaces the value of every string field that starts with
+// scheme followed by "://", such as kv://secrets/db for the scheme "kv", with
+// the value the resolver returns for it. Values are resolved after defaults
+// and environment variables are applied and before validation, so references
+// can come from any of them. No resolvers are built in, so backends such as
+// Vault or Consul can be plugged in without this package depending on them.
+//
+// Example:
+//
+//	kv := yamlconfig.ResolverFunc(func(ref string) (string, error) {
+//	    return vault.Read(strings.TrimPrefix(ref, "kv://"))
+//	})
+//
+//	err := yamlconfig.LoadConfig("config.yml", &cfg, yamlconfig.WithResolver("kv", kv))
+func WithResolver(scheme string, resolver Resolver) Option {
+	return func(o *options) {
+		if o.resolvers == nil {
+			o.resolvers = map[string]Resolver{}
+		}
+
+		o.resolvers[scheme] = resolver
+	}
+}
+
+// applyResolvers replaces the value of each string field holding a reference
+// to a registered scheme with the resolved value. References that cannot be
+// resolved are reported to errs.
+func applyResolvers(val reflect.Value, resolvers map[string]Resolver, errs *errorList) error {
+	if len(resolvers) == 0 {
+		return nil
+	}
+
+	return walkFields(val, "", func(field reflect.Value, _ reflect.StructField, path string) error {
+		if field.Kind() != reflect.String || !field.CanSet() {
+			return nil
+		}
+
+		ref := field.String()
+
+		scheme, _, ok := strings.Cut(ref, "://")
+		if !ok {
+			return nil
+		}
+
+		resolver, ok := resolvers[scheme]
+		if !ok {
+			return nil
+		}
+
+		value, resolveErr := resolver.Resolve(ref)
+		if resolveErr != nil {
+			return errs.add(fmt.Errorf("failed to resolve %s for %s: %w", ref, path, resolveErr))
+		}
+
+		field.SetString(value)
+
+		return nil
+	})
+}
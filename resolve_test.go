@@ -0,0 +1,65 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigResolve struct {
+	Database struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password" yamlconfig:"notblank"`
+	} `yaml:"database"`
+	Token string `yaml:"token" yamlconfig:"default=kv://secrets/token"`
+	URL   string `yaml:"url" yamlconfig:"omitempty"`
+}
+
+func TestResolver(t *testing.T) {
+	secrets := map[string]string{"kv://secrets/db": "s3cret", "kv://secrets/token": "abc", "kv://secrets/blank": " "}
+	kv := yamlconfig.WithResolver("kv", yamlconfig.ResolverFunc(func(ref string) (string, error) {
+		value, ok := secrets[ref]
+		if !ok {
+			return "", errors.New("no such secret")
+		}
+
+		return value, nil
+	}))
+
+	t.Run("References Resolved", func(t *testing.T) {
+		cfg := TestConfigResolve{}
+		path := writeTempConfig(t, "database:\n  user: kv\n  password: kv://secrets/db\nurl: https://example.com\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, kv))
+		require.Equal(t, "kv", cfg.Database.User)
+		require.Equal(t, "s3cret", cfg.Database.Password)
+		require.Equal(t, "abc", cfg.Token)
+		require.Equal(t, "https://example.com", cfg.URL)
+	})
+
+	t.Run("Unresolved Without Resolver", func(t *testing.T) {
+		cfg := TestConfigResolve{}
+		path := writeTempConfig(t, "database:\n  user: app\n  password: kv://secrets/db\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "kv://secrets/db", cfg.Database.Password)
+	})
+
+	t.Run("Resolve Error", func(t *testing.T) {
+		cfg := TestConfigResolve{}
+		path := writeTempConfig(t, "database:\n  user: app\n  password: kv://secrets/missing\ntoken: kv://secrets/other\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, kv, yamlconfig.WithErrorMode(yamlconfig.Collect)),
+			"failed to load the config: failed to resolve kv://secrets/missing for database.password: no such secret; "+
+				"failed to resolve kv://secrets/other for token: no such secret")
+	})
+
+	t.Run("Resolved Values Validated", func(t *testing.T) {
+		cfg := TestConfigResolve{}
+		path := writeTempConfig(t, "database:\n  user: app\n  password: kv://secrets/blank\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, kv), "failed to load the config: database.password: value must not be blank")
+	})
+}
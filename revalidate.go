@@ -0,0 +1,34 @@
+package yamlconfig
+
+import "gopkg.in/yaml.v3"
+
+// revalidate validates config again after it has been changed in place, with
+// the validation settings of o. When doc is the document the configuration
+// was loaded from, extended with the keys of the change, a field counts as
+// present when its key is in doc. Without it a field is missing whenever it
+// is empty.
+func revalidate(config interface{}, o *options, doc *yaml.Node) error {
+	v := newValidator(o)
+	if !o.valueBasedRequired {
+		v.doc = doc
+	}
+
+	return v.validateConfig(config)
+}
+
+// copyNode returns a deep copy of the node tree, so it can be changed without
+// affecting the original. Aliases keep pointing at the original anchors.
+func copyNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return &yaml.Node{Kind: yaml.DocumentNode}
+	}
+
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+
+	for i, child := range node.Content {
+		clone.Content[i] = copyNode(child)
+	}
+
+	return &clone
+}
@@ -0,0 +1,585 @@
+package yamlconfig
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// rule checks a field's value against the argument given to the rule in the
+// field's yamlconfig tag. The returned error describes the violation without
+// naming the field, which is added by the validator.
+type rule func(field reflect.Value, arg string) error
+
+// rules lists the validation rules that can be named in a yamlconfig tag, in
+// the order they are applied.
+var rules = []struct {
+	name  string
+	check rule
+}{
+	{"requiredkeys", validateRequiredKeys},
+	{"oneof", validateOneOf},
+	{"oneofci", validateOneOfCI},
+	{"notblank", validateNotBlank},
+	{"abspath", validateAbsPath},
+	{"min", validateMin},
+	{"max", validateMax},
+	{"gt", compareRule("gt", "greater than", "exclusive", func(order int) bool { return order > 0 })},
+	{"gte", compareRule("gte", "greater than or equal to", "inclusive", func(order int) bool { return order >= 0 })},
+	{"lt", compareRule("lt", "less than", "exclusive", func(order int) bool { return order < 0 })},
+	{"lte", compareRule("lte", "less than or equal to", "inclusive", func(order int) bool { return order <= 0 })},
+	{"multipleof", validateMultipleOf},
+	{"scale", validateScale},
+	{"format", validateFormat},
+	{"sorted", validateSorted},
+	{"unique", validateUnique},
+	{"uniquevalues", validateUniqueValues},
+	{"minlen", validateMinLen},
+	{"maxlen", validateMaxLen},
+}
+
+// validateRules applies the validation rules named in a field's yamlconfig tag
+// to its value, or to each of its elements when the field is tagged csv.
+// Rules are only checked for fields that are not empty.
+func validateRules(field reflect.Value, tag fieldTag) error {
+	if tag.has("csv") {
+		return validateElements(field, tag)
+	}
+
+	return applyRules(field, tag)
+}
+
+// applyRules applies the validation rules named in the tag to the value.
+func applyRules(field reflect.Value, tag fieldTag) error {
+	for _, r := range rules {
+		if arg, ok := tag.get(r.name); ok {
+			if err := r.check(field, arg); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOneOf checks that the field's value equals one of the allowed
+// values. The allowed values are parsed according to the field's kind so that
+// numeric fields are compared as numbers. Each element of a slice or array is
+// checked in turn.
+func validateOneOf(field reflect.Value, arg string) error {
+	if field.Kind() == reflect.Slice || field.Kind() == reflect.Array {
+		for i := 0; i < field.Len(); i++ {
+			if err := validateOneOf(field.Index(i), arg); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+
+		return nil
+	}
+
+	allowed := strings.Fields(arg)
+
+	for _, candidate := range allowed {
+		match, err := equalsToken(field, candidate)
+		if err != nil {
+			return fmt.Errorf("invalid oneof value %q: %w", candidate, err)
+		}
+
+		if match {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("value %v must be one of: %s", field, strings.Join(allowed, " "))
+}
+
+// validateOneOfCI checks that the string field equals one of the allowed
+// values ignoring case. The field is not changed; tag it yamlconfig:"canonical"
+// as well to have the transform pass rewrite it to the casing used in the tag.
+func validateOneOfCI(field reflect.Value, arg string) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("oneofci is only supported on string fields")
+	}
+
+	allowed := strings.Fields(arg)
+
+	if _, ok := canonicalCase(field.String(), allowed); ok {
+		return nil
+	}
+
+	return fmt.Errorf("value %v must be one of: %s (case-insensitive)", field, strings.Join(allowed, " "))
+}
+
+// canonicalCase returns the allowed value equal to value ignoring case.
+func canonicalCase(value string, allowed []string) (string, bool) {
+	for _, candidate := range allowed {
+		if strings.EqualFold(value, candidate) {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// equalsToken reports whether the field's value equals the token parsed as a
+// value of the field's kind.
+func equalsToken(field reflect.Value, token string) (bool, error) {
+	switch field.Kind() { //nolint:exhaustive // Only scalar kinds can be compared
+	case reflect.String:
+		return field.String() == token, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(token, 10, 64)
+
+		return err == nil && field.Int() == n, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(token, 10, 64)
+
+		return err == nil && field.Uint() == n, err
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(token, field.Type().Bits())
+
+		return err == nil && field.Float() == n, err
+	}
+
+	return false, fmt.Errorf("oneof is not supported on %s fields", field.Kind())
+}
+
+// validateMin checks that the field's value is not below the bound, which is
+// parsed in the same units as the field.
+func validateMin(field reflect.Value, arg string) error {
+	order, err := compareToken(field, arg)
+	if err != nil {
+		return fmt.Errorf("invalid min value %q: %w", arg, err)
+	}
+
+	if order < 0 {
+		return fmt.Errorf("value %v is less than min=%s", field, arg)
+	}
+
+	return nil
+}
+
+// validateMax checks that the field's value is not above the bound, which is
+// parsed in the same units as the field.
+func validateMax(field reflect.Value, arg string) error {
+	order, err := compareToken(field, arg)
+	if err != nil {
+		return fmt.Errorf("invalid max value %q: %w", arg, err)
+	}
+
+	if order > 0 {
+		return fmt.Errorf("value %v exceeds max=%s", field, arg)
+	}
+
+	return nil
+}
+
+// compareRule returns a rule comparing the field's value with the bound given
+// as its argument, parsed in the same units as the field. The value passes
+// when ok reports true for the order of the value against the bound. The
+// relation and whether the bound is exclusive are named in the error.
+func compareRule(name, relation, bound string, ok func(order int) bool) rule {
+	return func(field reflect.Value, arg string) error {
+		order, err := compareToken(field, arg)
+		if err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", name, arg, err)
+		}
+
+		if !ok(order) {
+			return fmt.Errorf("value %v must be %s %s (%s=%s, %s)", field, relation, arg, name, arg, bound)
+		}
+
+		return nil
+	}
+}
+
+// compareToken compares the field's value with the token parsed in the same
+// units as the field, returning -1, 0 or 1. time.Duration fields take bounds
+// such as "5m" and ByteSize fields take bounds such as "1MB".
+func compareToken(field reflect.Value, token string) (int, error) {
+	switch field.Type() {
+	case reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(token)
+
+		return cmp.Compare(time.Duration(field.Int()), d), err
+	case reflect.TypeOf(ByteSize(0)):
+		b, err := ParseByteSize(token)
+
+		return cmp.Compare(ByteSize(field.Uint()), b), err
+	}
+
+	switch field.Kind() { //nolint:exhaustive // Only numeric kinds are ordered
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(token, 10, 64)
+
+		return cmp.Compare(field.Int(), n), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(token, 10, 64)
+
+		return cmp.Compare(field.Uint(), n), err
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(token, 64)
+
+		return cmp.Compare(field.Float(), n), err
+	}
+
+	return 0, fmt.Errorf("bounds are not supported on %s fields", field.Kind())
+}
+
+// validateMultipleOf checks that the integer field's value is a multiple of
+// the divisor, which is parsed in the same units as the field so that
+// multipleof=4KiB and multipleof=1s work on ByteSize and time.Duration fields.
+func validateMultipleOf(field reflect.Value, arg string) error {
+	var (
+		divisor uint64
+		value   uint64
+		err     error
+	)
+
+	switch {
+	case field.Type() == reflect.TypeOf(time.Duration(0)):
+		var d time.Duration
+		d, err = time.ParseDuration(arg)
+		divisor, value = uint64(d.Abs()), uint64(time.Duration(field.Int()).Abs())
+	case field.Type() == reflect.TypeOf(ByteSize(0)):
+		var b ByteSize
+		b, err = ParseByteSize(arg)
+		divisor, value = uint64(b), field.Uint()
+	case field.CanInt():
+		var n int64
+		n, err = strconv.ParseInt(arg, 10, 64)
+		divisor, value = absInt(n), absInt(field.Int())
+	case field.CanUint():
+		divisor, err = strconv.ParseUint(arg, 10, 64)
+		value = field.Uint()
+	default:
+		return fmt.Errorf("multipleof is only supported on integer fields")
+	}
+
+	if err != nil || divisor == 0 {
+		return fmt.Errorf("invalid multipleof value %q", arg)
+	}
+
+	if value%divisor != 0 {
+		return fmt.Errorf("value %v is not a multiple of %s", field, arg)
+	}
+
+	return nil
+}
+
+// validateScale checks that the float field has at most the given number of
+// decimal places. The value is compared in its shortest decimal form, so 0.1
+// has one decimal place despite not being exact in binary.
+func validateScale(field reflect.Value, arg string) error {
+	if !field.CanFloat() {
+		return fmt.Errorf("scale is only supported on float fields")
+	}
+
+	places, err := strconv.Atoi(arg)
+	if err != nil || places < 0 {
+		return fmt.Errorf("invalid scale value %q", arg)
+	}
+
+	formatted := strconv.FormatFloat(field.Float(), 'f', -1, field.Type().Bits())
+	if _, fraction, ok := strings.Cut(formatted, "."); ok && len(fraction) > places {
+		return fmt.Errorf("value %s has more than %d decimal places", formatted, places)
+	}
+
+	return nil
+}
+
+// absInt returns the absolute value of n.
+func absInt(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+
+	return uint64(n)
+}
+
+// validateMinLen checks that the field's length is at least the argument.
+func validateMinLen(field reflect.Value, arg string) error {
+	length, limit, unit, err := lengthOf(field, arg)
+	if err != nil {
+		return fmt.Errorf("invalid minlen %q: %w", arg, err)
+	}
+
+	if length < limit {
+		return fmt.Errorf("length %d %s is less than minlen=%d", length, unit, limit)
+	}
+
+	return nil
+}
+
+// validateMaxLen checks that the field's length is at most the argument.
+func validateMaxLen(field reflect.Value, arg string) error {
+	length, limit, unit, err := lengthOf(field, arg)
+	if err != nil {
+		return fmt.Errorf("invalid maxlen %q: %w", arg, err)
+	}
+
+	if length > limit {
+		return fmt.Errorf("length %d %s exceeds maxlen=%d", length, unit, limit)
+	}
+
+	return nil
+}
+
+// lengthOf parses a length rule argument of the form "n" or "n:unit" and
+// measures the field in that unit. Strings are measured in runes unless the
+// unit is "bytes", slices and maps in elements.
+func lengthOf(field reflect.Value, arg string) (length, limit int, unit string, err error) {
+	value, unit, hasUnit := strings.Cut(arg, ":")
+
+	limit, err = strconv.Atoi(value)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	switch field.Kind() { //nolint:exhaustive // Only kinds with a length are measured
+	case reflect.String:
+		switch unit {
+		case "", "runes":
+			return utf8.RuneCountInString(field.String()), limit, "runes", nil
+		case "bytes":
+			return len(field.String()), limit, "bytes", nil
+		}
+
+		return 0, 0, "", fmt.Errorf("unknown unit %q, must be runes or bytes", unit)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if hasUnit {
+			return 0, 0, "", fmt.Errorf("units are only supported on string fields")
+		}
+
+		return field.Len(), limit, "elements", nil
+	}
+
+	return 0, 0, "", fmt.Errorf("length is not supported on %s fields", field.Kind())
+}
+
+// validateSorted checks that the elements of the slice field are in
+// non-decreasing order, or non-increasing order when the argument is "desc".
+func validateSorted(field reflect.Value, arg string) error {
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return fmt.Errorf("sorted is only supported on slice fields")
+	}
+
+	if arg != "" && arg != "asc" && arg != "desc" {
+		return fmt.Errorf("invalid sorted order %q, must be asc or desc", arg)
+	}
+
+	for i := 1; i < field.Len(); i++ {
+		order, err := compareValues(field.Index(i-1), field.Index(i))
+		if err != nil {
+			return err
+		}
+
+		if arg == "desc" && order < 0 {
+			return fmt.Errorf("element %d (%v) is greater than the element before it, must be sorted in descending order", i, field.Index(i))
+		}
+
+		if arg != "desc" && order > 0 {
+			return fmt.Errorf("element %d (%v) is less than the element before it, must be sorted in ascending order", i, field.Index(i))
+		}
+	}
+
+	return nil
+}
+
+// validateUnique checks that no two elements of the slice or array field are
+// equal, naming the first repeated element and the element it repeats.
+func validateUnique(field reflect.Value, _ string) error {
+	if (field.Kind() != reflect.Slice && field.Kind() != reflect.Array) || !field.Type().Elem().Comparable() {
+		return fmt.Errorf("unique is only supported on slices with comparable elements")
+	}
+
+	seen := newValueSet()
+
+	for i := 0; i < field.Len(); i++ {
+		element := field.Index(i)
+		if first, ok := seen.find(element); ok {
+			return fmt.Errorf("element %d (%v) is a duplicate of element %d", i, element, first)
+		}
+
+		seen.add(element, i)
+	}
+
+	return nil
+}
+
+// valueSet records values along with a position, such as their index, to find
+// a value equal to one seen before. Values are compared with == through a map
+// where they can be, and with reflect.DeepEqual otherwise, since a comparable
+// interface type can hold maps or slices that cannot be map keys.
+type valueSet struct {
+	// positions holds the position of each value that can be a map key.
+	positions map[interface{}]int
+	// others and otherPositions hold the values that cannot be map keys and
+	// their positions.
+	others         []reflect.Value
+	otherPositions []int
+}
+
+// newValueSet returns an empty valueSet.
+func newValueSet() *valueSet {
+	return &valueSet{positions: map[interface{}]int{}}
+}
+
+// find returns the position recorded with a value equal to value.
+func (s *valueSet) find(value reflect.Value) (int, bool) {
+	if value.Comparable() {
+		position, ok := s.positions[value.Interface()]
+
+		return position, ok
+	}
+
+	for i, other := range s.others {
+		if reflect.DeepEqual(other.Interface(), value.Interface()) {
+			return s.otherPositions[i], true
+		}
+	}
+
+	return 0, false
+}
+
+// add records value with its position.
+func (s *valueSet) add(value reflect.Value, position int) {
+	if value.Comparable() {
+		s.positions[value.Interface()] = position
+
+		return
+	}
+
+	s.others = append(s.others, value)
+	s.otherPositions = append(s.otherPositions, position)
+}
+
+// validateUniqueValues checks that no two keys of the map field hold the same
+// value, naming the keys that share each repeated value.
+func validateUniqueValues(field reflect.Value, _ string) error {
+	if field.Kind() != reflect.Map || !field.Type().Elem().Comparable() {
+		return fmt.Errorf("uniquevalues is only supported on maps with comparable values")
+	}
+
+	keys := field.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	// Group the keys by value, in the order each value is first seen
+	var (
+		values []reflect.Value
+		owners [][]string
+		seen   = newValueSet()
+	)
+
+	for _, key := range keys {
+		value := field.MapIndex(key)
+
+		group, ok := seen.find(value)
+		if !ok {
+			group = len(values)
+			values = append(values, value)
+			owners = append(owners, nil)
+			seen.add(value, group)
+		}
+
+		owners[group] = append(owners[group], fmt.Sprint(key))
+	}
+
+	var duplicates []string
+
+	for group, value := range values {
+		if len(owners[group]) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("value %v is used by more than one key: %s", value, strings.Join(owners[group], ", ")))
+		}
+	}
+
+	if len(duplicates) > 0 {
+		return errors.New(strings.Join(duplicates, "; "))
+	}
+
+	return nil
+}
+
+// compareValues compares two scalar values of the same kind, returning -1, 0
+// or 1.
+func compareValues(a, b reflect.Value) (int, error) {
+	switch a.Kind() { //nolint:exhaustive // Only scalar kinds are ordered
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), nil
+	}
+
+	return 0, fmt.Errorf("ordering is not supported on %s values", a.Kind())
+}
+
+// validateNotBlank checks that the string field contains something other than
+// whitespace.
+func validateNotBlank(field reflect.Value, _ string) error {
+	if field.Kind() != reflect.String {
+		return fmt.Errorf("notblank is only supported on string fields")
+	}
+
+	if strings.TrimSpace(field.String()) == "" {
+		return fmt.Errorf("value must not be blank")
+	}
+
+	return nil
+}
+
+// validateAbsPath checks that the string field, or each element of the string
+// slice field, is an absolute path, such as a pidfile or unix socket location
+// that must not depend on the working directory.
+func validateAbsPath(field reflect.Value, _ string) error {
+	switch {
+	case field.Kind() == reflect.String:
+		if !filepath.IsAbs(field.String()) {
+			return fmt.Errorf("value %q is not an absolute path", field.String())
+		}
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < field.Len(); i++ {
+			if element := field.Index(i).String(); !filepath.IsAbs(element) {
+				return fmt.Errorf("element %d: value %q is not an absolute path", i, element)
+			}
+		}
+	default:
+		return fmt.Errorf("abspath is only supported on string and string slice fields")
+	}
+
+	return nil
+}
+
+// validateRequiredKeys checks that the map field contains every key in the
+// space separated list.
+func validateRequiredKeys(field reflect.Value, arg string) error {
+	if field.Kind() != reflect.Map || field.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("requiredkeys is only supported on maps with string keys")
+	}
+
+	var missing []string
+
+	for _, key := range strings.Fields(arg) {
+		if !field.MapIndex(reflect.ValueOf(key).Convert(field.Type().Key())).IsValid() {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required keys: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,394 @@
+package yamlconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigRequiredKeys struct {
+	Labels map[string]string `yaml:"labels" yamlconfig:"requiredkeys=region zone"`
+}
+
+type TestConfigOneOf struct {
+	Level   string  `yaml:"level" yamlconfig:"oneof=debug info warn error"`
+	Version int     `yaml:"version" yamlconfig:"oneof=1 2 3"`
+	Mode    uint    `yaml:"mode" yamlconfig:"omitempty,oneof=4 8"`
+	Ratio   float64 `yaml:"ratio" yamlconfig:"omitempty,oneof=0.5 1.5"`
+}
+
+type TestConfigNotBlank struct {
+	Name string `yaml:"name" yamlconfig:"notblank"`
+}
+
+type TestConfigBounds struct {
+	Workers int                 `yaml:"workers" yamlconfig:"omitempty,min=1,max=64"`
+	Ratio   float64             `yaml:"ratio" yamlconfig:"omitempty,max=1"`
+	Timeout time.Duration       `yaml:"timeout" yamlconfig:"omitempty,min=1s,max=5m"`
+	Buffer  yamlconfig.ByteSize `yaml:"buffer" yamlconfig:"omitempty,min=1MB"`
+}
+
+type TestConfigOneOfCI struct {
+	Level string `yaml:"level" yamlconfig:"oneofci=debug info warn error,canonical"`
+	Mode  string `yaml:"mode" yamlconfig:"omitempty,oneofci=fast safe"`
+}
+
+type TestConfigSorted struct {
+	Thresholds []int     `yaml:"thresholds" yamlconfig:"omitempty,sorted"`
+	Priorities []float64 `yaml:"priorities" yamlconfig:"omitempty,sorted=desc"`
+	Names      []string  `yaml:"names" yamlconfig:"omitempty,sorted"`
+}
+
+type TestConfigMultipleOf struct {
+	Buffer   int                 `yaml:"buffer" yamlconfig:"multipleof=4096"`
+	Pages    uint                `yaml:"pages" yamlconfig:"omitempty,multipleof=2"`
+	Interval time.Duration       `yaml:"interval" yamlconfig:"omitempty,multipleof=15s"`
+	Block    yamlconfig.ByteSize `yaml:"block" yamlconfig:"omitempty,multipleof=4KiB"`
+}
+
+type TestConfigAbsPath struct {
+	PidFile string   `yaml:"pid_file" yamlconfig:"abspath"`
+	Sockets []string `yaml:"sockets" yamlconfig:"omitempty,abspath"`
+	Port    int      `yaml:"port" yamlconfig:"omitempty,abspath"`
+}
+
+type TestConfigScale struct {
+	Price float64 `yaml:"price" yamlconfig:"scale=2"`
+	Ratio float32 `yaml:"ratio" yamlconfig:"omitempty,scale=1"`
+	Count int     `yaml:"count" yamlconfig:"omitempty,scale=1"`
+}
+
+type TestConfigScopes struct {
+	Scopes []string `yaml:"scopes" yamlconfig:"oneof=read write admin,unique"`
+	Ports  []int    `yaml:"ports" yamlconfig:"omitempty,unique"`
+	Roles  string   `yaml:"roles" yamlconfig:"omitempty,csv,oneof=dev ops,unique"`
+}
+
+type TestConfigUniqueValues struct {
+	Routes map[string]string `yaml:"routes" yamlconfig:"uniquevalues"`
+}
+
+type TestConfigLength struct {
+	Name   string   `yaml:"name" yamlconfig:"omitempty,minlen=2,maxlen=4"`
+	Column string   `yaml:"column" yamlconfig:"omitempty,maxlen=4:bytes"`
+	Tags   []string `yaml:"tags" yamlconfig:"omitempty,maxlen=2"`
+}
+
+type TestConfigCompare struct {
+	Ratio   float64       `yaml:"ratio" yamlconfig:"omitempty,gt=0,lt=1"`
+	Workers int           `yaml:"workers" yamlconfig:"omitempty,gte=1,lte=8"`
+	Timeout time.Duration `yaml:"timeout" yamlconfig:"omitempty,gt=0s"`
+}
+
+func TestRules(t *testing.T) {
+	t.Run("Required Keys Present", func(t *testing.T) {
+		cfg := TestConfigRequiredKeys{}
+		path := writeTempConfig(t, "labels:\n  region: eu\n  zone: a\n  extra: x\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Required Keys Missing", func(t *testing.T) {
+		cfg := TestConfigRequiredKeys{}
+		path := writeTempConfig(t, "labels:\n  extra: x\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg)
+		require.ErrorContains(t, loadConfigErr, "labels: missing required keys: region, zone")
+	})
+
+	t.Run("One Of Allowed Values", func(t *testing.T) {
+		cfg := TestConfigOneOf{}
+		path := writeTempConfig(t, "level: info\nversion: 2\nmode: 8\nratio: 1.5\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("One Of String Not Allowed", func(t *testing.T) {
+		cfg := TestConfigOneOf{}
+		path := writeTempConfig(t, "level: trace\nversion: 2\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "level: value trace must be one of: debug info warn error")
+	})
+
+	t.Run("One Of Int Not Allowed", func(t *testing.T) {
+		cfg := TestConfigOneOf{}
+		path := writeTempConfig(t, "level: info\nversion: 4\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "version: value 4 must be one of: 1 2 3")
+	})
+
+	t.Run("One Of Uint And Float Not Allowed", func(t *testing.T) {
+		cfg := TestConfigOneOf{}
+		path := writeTempConfig(t, "level: info\nversion: 1\nmode: 5\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "mode: value 5 must be one of: 4 8")
+
+		cfg = TestConfigOneOf{}
+		path = writeTempConfig(t, "level: info\nversion: 1\nratio: 0.25\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "ratio: value 0.25 must be one of: 0.5 1.5")
+	})
+
+	t.Run("Not Blank", func(t *testing.T) {
+		cfg := TestConfigNotBlank{}
+		path := writeTempConfig(t, "name: \"   \"\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "name: value must not be blank")
+
+		cfg = TestConfigNotBlank{}
+		path = writeTempConfig(t, "name: \" app \"\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Min Max Within Bounds", func(t *testing.T) {
+		cfg := TestConfigBounds{}
+		path := writeTempConfig(t, "workers: 64\nratio: 0.5\ntimeout: 30s\nbuffer: 2MB\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Min Max Out Of Bounds", func(t *testing.T) {
+		tests := map[string]string{
+			"workers: 65\n":    "workers: value 65 exceeds max=64",
+			"ratio: 1.5\n":     "ratio: value 1.5 exceeds max=1",
+			"timeout: 10m\n":   "timeout: value 10m0s exceeds max=5m",
+			"timeout: 500ms\n": "timeout: value 500ms is less than min=1s",
+			"buffer: 900KB\n":  "buffer: value 900KB is less than min=1MB",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigBounds{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+		}
+	})
+
+	t.Run("One Of Case Insensitive", func(t *testing.T) {
+		cfg := TestConfigOneOfCI{}
+		path := writeTempConfig(t, "level: INFO\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "info", cfg.Level)
+
+		cfg = TestConfigOneOfCI{}
+		path = writeTempConfig(t, "level: Trace\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "level: value Trace must be one of: debug info warn error (case-insensitive)")
+
+		cfg = TestConfigOneOfCI{}
+		path = writeTempConfig(t, "level: info\nmode: FAST\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "FAST", cfg.Mode)
+
+		// Validating again leaves the value as it is
+		cfg.Level = "WARN"
+		require.NoError(t, yamlconfig.ApplyPatch(&cfg, []byte("mode: Safe\n")))
+		require.Equal(t, "WARN", cfg.Level)
+		require.Equal(t, "Safe", cfg.Mode)
+
+		misplaced := struct {
+			Level string `yaml:"level" yamlconfig:"canonical"`
+		}{}
+		require.ErrorContains(t, yamlconfig.LoadConfig(writeTempConfig(t, "level: info\n"), &misplaced),
+			"canonical is only supported on string fields tagged oneofci: level")
+	})
+
+	t.Run("Sorted Slices", func(t *testing.T) {
+		cfg := TestConfigSorted{}
+		path := writeTempConfig(t, "thresholds: [1, 5, 5, 10]\npriorities: [3.5, 2, 2]\nnames: [a, b, c]\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Unsorted Slices", func(t *testing.T) {
+		tests := map[string]string{
+			"thresholds: [1, 10, 5]\n": "thresholds: element 2 (5) is less than the element before it, must be sorted in ascending order",
+			"priorities: [1, 2]\n":     "priorities: element 1 (2) is greater than the element before it, must be sorted in descending order",
+			"names: [b, a]\n":          "names: element 1 (a) is less than the element before it",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigSorted{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+		}
+	})
+
+	t.Run("Multiple Of", func(t *testing.T) {
+		cfg := TestConfigMultipleOf{}
+		path := writeTempConfig(t, "buffer: 8192\npages: 4\ninterval: 1m\nblock: 8KiB\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		tests := map[string]string{
+			"buffer: 5000\n":                "buffer: value 5000 is not a multiple of 4096",
+			"buffer: 4096\npages: 3\n":      "pages: value 3 is not a multiple of 2",
+			"buffer: 4096\ninterval: 20s\n": "interval: value 20s is not a multiple of 15s",
+			"buffer: 4096\nblock: 6KiB\n":   "block: value 6KiB is not a multiple of 4KiB",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigMultipleOf{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: "+expected)
+		}
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		cfg := TestConfigScale{}
+		path := writeTempConfig(t, "price: 19.99\nratio: 0.1\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg = TestConfigScale{}
+		path = writeTempConfig(t, "price: 20\nratio: 0.3\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		tests := map[string]string{
+			"price: 0.333333\n":       "price: value 0.333333 has more than 2 decimal places",
+			"price: 1.005\n":          "price: value 1.005 has more than 2 decimal places",
+			"price: 1\nratio: 0.25\n": "ratio: value 0.25 has more than 1 decimal places",
+			"price: 1\ncount: 2\n":    "count: scale is only supported on float fields",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigScale{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: "+expected)
+		}
+	})
+
+	t.Run("Absolute Paths", func(t *testing.T) {
+		cfg := TestConfigAbsPath{}
+		path := writeTempConfig(t, "pid_file: /run/app.pid\nsockets: [/run/app.sock, /tmp/app.sock]\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		tests := map[string]string{
+			"pid_file: app.pid\n":                            "pid_file: value \"app.pid\" is not an absolute path",
+			"pid_file: ./run/app.pid\n":                      "pid_file: value \"./run/app.pid\" is not an absolute path",
+			"pid_file: /run/app.pid\nsockets: [/a, run/b]\n": "sockets: element 1: value \"run/b\" is not an absolute path",
+			"pid_file: /run/app.pid\nport: 80\n":             "port: abspath is only supported on string and string slice fields",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigAbsPath{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: "+expected)
+		}
+	})
+
+	t.Run("Distinct Set Members", func(t *testing.T) {
+		cfg := TestConfigScopes{}
+		path := writeTempConfig(t, "scopes: [read, admin]\nports: [80, 443]\nroles: dev, ops\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		tests := map[string]string{
+			"scopes: [read, delete]\n":               "scopes: element 1: value delete must be one of: read write admin",
+			"scopes: [read, write, read]\n":          "scopes: element 2 (read) is a duplicate of element 0",
+			"scopes: [read]\nports: [80, 443, 80]\n": "ports: element 2 (80) is a duplicate of element 0",
+			"scopes: [read]\nroles: dev, dev\n":      "roles: element 1 (dev) is a duplicate of element 0",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigScopes{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: "+expected)
+		}
+	})
+
+	t.Run("Distinct Members Holding Collections", func(t *testing.T) {
+		cfg := struct {
+			Items []interface{} `yaml:"items" yamlconfig:"unique"`
+		}{}
+		path := writeTempConfig(t, "items: [a, [1, 2], {x: 1}, [1, 3]]\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg.Items = nil
+		path = writeTempConfig(t, "items: [a, {x: 1}, [1, 2], {x: 1}]\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: items: element 3 (map[x:1]) is a duplicate of element 1")
+	})
+
+	t.Run("Unique Map Values", func(t *testing.T) {
+		cfg := TestConfigUniqueValues{}
+		path := writeTempConfig(t, "routes:\n  /api: api-svc\n  /web: web-svc\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg = TestConfigUniqueValues{}
+		path = writeTempConfig(t, "routes:\n  /web: web-svc\n  /api: api-svc\n  /v2: api-svc\n  /app: web-svc\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: routes: "+
+			"value api-svc is used by more than one key: /api, /v2; value web-svc is used by more than one key: /app, /web")
+	})
+
+	t.Run("Unique Map Values Holding Collections", func(t *testing.T) {
+		cfg := struct {
+			Pools map[string]interface{} `yaml:"pools" yamlconfig:"uniquevalues"`
+		}{}
+		path := writeTempConfig(t, "pools:\n  a: [1, 2]\n  b: {x: 1}\n  c: [1, 3]\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		cfg.Pools = nil
+		path = writeTempConfig(t, "pools:\n  a: [1, 2]\n  b: {x: 1}\n  c: [1, 2]\n  d: one\n")
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: pools: "+
+			"value [1 2] is used by more than one key: a, c")
+	})
+
+	t.Run("Length Counts Runes By Default", func(t *testing.T) {
+		cfg := TestConfigLength{}
+		path := writeTempConfig(t, "name: héllo\ncolumn: abcd\ntags: [a, b]\n")
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "name: length 5 runes exceeds maxlen=4")
+
+		cfg = TestConfigLength{}
+		path = writeTempConfig(t, "name: héll\ncolumn: abcd\ntags: [a, b]\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Length Out Of Bounds", func(t *testing.T) {
+		tests := map[string]string{
+			"name: a\n":           "name: length 1 runes is less than minlen=2",
+			"column: héll\n":      "column: length 5 bytes exceeds maxlen=4",
+			"tags: [a, b, c]\n":   "tags: length 3 elements exceeds maxlen=2",
+			"column: \"ab cd\"\n": "column: length 5 bytes exceeds maxlen=4",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigLength{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+		}
+	})
+
+	t.Run("Length Invalid Unit", func(t *testing.T) {
+		cfg := struct {
+			Name string `yaml:"name" yamlconfig:"maxlen=4:chars"`
+		}{}
+		path := writeTempConfig(t, "name: app\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), `name: invalid maxlen "4:chars": unknown unit "chars", must be runes or bytes`)
+	})
+
+	t.Run("Comparisons Within Bounds", func(t *testing.T) {
+		cfg := TestConfigCompare{}
+		path := writeTempConfig(t, "ratio: 0.5\nworkers: 8\ntimeout: 1ms\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+	})
+
+	t.Run("Comparisons Out Of Bounds", func(t *testing.T) {
+		tests := map[string]string{
+			"ratio: 0\n":     "ratio: value 0 must be greater than 0 (gt=0, exclusive)",
+			"ratio: 1\n":     "ratio: value 1 must be less than 1 (lt=1, exclusive)",
+			"workers: 0\n":   "workers: value 0 must be greater than or equal to 1 (gte=1, inclusive)",
+			"workers: 9\n":   "workers: value 9 must be less than or equal to 8 (lte=8, inclusive)",
+			"timeout: -1s\n": "timeout: value -1s must be greater than 0s (gt=0s, exclusive)",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigCompare{}
+			path := writeTempConfig(t, content)
+
+			require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), expected)
+		}
+	})
+}
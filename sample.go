@@ -0,0 +1,180 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sampleIndent is the indentation used for each level of a generated sample.
+const sampleIndent = "  "
+
+// GenerateSample generates a ready to edit YAML configuration file from a
+// configuration struct. Every field is listed with its default value if it
+// has one, or a placeholder otherwise, followed by a comment giving its type
+// and whether it is required. Optional fields are commented out.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+//
+// Returns:
+// []byte: The sample YAML document.
+// error: An error if config is not a struct.
+//
+// Example:
+//
+// sample, err := yamlconfig.GenerateSample(&Config{})
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func GenerateSample(config interface{}) ([]byte, error) {
+	typ := reflect.TypeOf(config)
+	if typ == nil || derefType(typ).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, please ensure the input is a struct or struct pointer")
+	}
+
+	lines := sampleLines(derefType(typ), map[reflect.Type]bool{})
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// sampleLines returns the lines describing the fields of the struct type typ,
+// without any indentation for the struct itself. Seen holds the struct types
+// being described further up, so a type that refers to itself is expanded
+// once rather than forever.
+func sampleLines(typ reflect.Type, seen map[reflect.Type]bool) []string {
+	var lines []string
+
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, inline, skip := yamlKey(field)
+		if skip {
+			continue
+		}
+
+		// An inlined map holds whatever keys are left over, so there is
+		// nothing of its own to show
+		if inline {
+			if fieldType := derefType(field.Type); fieldType.Kind() == reflect.Struct {
+				lines = append(lines, sampleLines(fieldType, seen)...)
+			}
+
+			continue
+		}
+
+		tag := parseTag(field.Tag.Get("yamlconfig"))
+
+		required := "required"
+		if tag.has("omitempty") {
+			required = "optional"
+		}
+
+		fieldLines := sampleField(name, field.Type, tag, required, seen)
+		if tag.has("omitempty") {
+			fieldLines = commentLines(fieldLines)
+		}
+
+		lines = append(lines, fieldLines...)
+	}
+
+	return lines
+}
+
+// sampleField returns the lines describing a single field. A struct already
+// being described further up is shown empty rather than expanded again.
+func sampleField(name string, typ reflect.Type, tag fieldTag, required string, seen map[reflect.Type]bool) []string {
+	fieldType := derefType(typ)
+	comment := "  # " + describeType(typ) + ", " + required
+
+	switch {
+	case fieldType.Kind() == reflect.Struct && !isScalarStruct(fieldType) && seen[fieldType]:
+		return []string{name + ": {}" + comment}
+	case fieldType.Kind() == reflect.Struct && !isScalarStruct(fieldType):
+		return append([]string{name + ":" + comment}, indentLines(sampleLines(fieldType, seen), sampleIndent, sampleIndent)...)
+	case fieldType.Kind() == reflect.Slice && derefType(fieldType.Elem()).Kind() == reflect.Struct && !seen[derefType(fieldType.Elem())]:
+		return append([]string{name + ":" + comment}, indentLines(sampleLines(derefType(fieldType.Elem()), seen), sampleIndent+"- ", sampleIndent+"  ")...)
+	}
+
+	return []string{name + ": " + sampleValue(fieldType, tag) + comment}
+}
+
+// sampleValue returns the YAML value shown for a scalar or collection field:
+// its default if it has one, or a placeholder for its type.
+func sampleValue(typ reflect.Type, tag fieldTag) string {
+	if value, ok := tag.get("default"); ok {
+		switch typ.Kind() { //nolint:exhaustive // Only strings and slices need formatting
+		case reflect.String:
+			return quoteScalar(value)
+		case reflect.Slice:
+			return "[" + strings.Join(strings.Fields(value), ", ") + "]"
+		}
+
+		return value
+	}
+
+	switch typ.Kind() { //nolint:exhaustive // Other kinds use an empty string placeholder
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "0"
+	case reflect.Float32, reflect.Float64:
+		return "0.0"
+	case reflect.Bool:
+		return "false"
+	case reflect.Slice, reflect.Array:
+		return "[]"
+	case reflect.Map:
+		return "{}"
+	}
+
+	return `""`
+}
+
+// isScalarStruct reports whether the struct type is written as a single YAML
+// scalar rather than a mapping, such as time.Time.
+func isScalarStruct(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()) ||
+		typ.PkgPath() == "time"
+}
+
+// quoteScalar returns the string formatted as a YAML scalar, quoted if needed.
+func quoteScalar(s string) string {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// indentLines prefixes the first line with first and every other line with
+// rest.
+func indentLines(lines []string, first, rest string) []string {
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+
+	return lines
+}
+
+// commentLines comments out every line that is not already a comment.
+func commentLines(lines []string) []string {
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines[i] = "# " + line
+		}
+	}
+
+	return lines
+}
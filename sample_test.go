@@ -0,0 +1,78 @@
+package yamlconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type TestConfigSample struct {
+	Name    string        `yaml:"name"`
+	Mode    string        `yaml:"mode" yamlconfig:"default=fast mode"`
+	Timeout time.Duration `yaml:"timeout" yamlconfig:"default=5s"`
+	Methods []string      `yaml:"methods" yamlconfig:"default=GET HEAD"`
+	Debug   bool          `yaml:"debug" yamlconfig:"omitempty"`
+	Servers []struct {
+		Address string `yaml:"address"`
+		Port    int    `yaml:"port"`
+	} `yaml:"servers"`
+	TLS struct {
+		Cert string `yaml:"cert"`
+		Key  string `yaml:"key" yamlconfig:"omitempty"`
+	} `yaml:"tls" yamlconfig:"omitempty"`
+}
+
+func TestGenerateSample(t *testing.T) {
+	t.Run("Generate Sample", func(t *testing.T) {
+		sample, generateErr := yamlconfig.GenerateSample(&TestConfigSample{})
+		require.NoError(t, generateErr)
+
+		require.Equal(t, `name: ""  # string, required
+mode: fast mode  # string, required
+timeout: 5s  # time.Duration, required
+methods: [GET, HEAD]  # []string, required
+# debug: false  # bool, optional
+servers:  # []struct, required
+  - address: ""  # string, required
+    port: 0  # int, required
+# tls:  # struct, optional
+#   cert: ""  # string, required
+  # key: ""  # string, optional
+`, string(sample))
+	})
+
+	t.Run("Generate Sample Is Valid YAML", func(t *testing.T) {
+		sample, generateErr := yamlconfig.GenerateSample(TestConfigSample{})
+		require.NoError(t, generateErr)
+
+		cfg := TestConfigSample{}
+		require.NoError(t, yaml.Unmarshal(sample, &cfg))
+		require.Equal(t, 5*time.Second, cfg.Timeout)
+		require.Len(t, cfg.Servers, 1)
+	})
+
+	t.Run("Generate Sample Of Non Struct", func(t *testing.T) {
+		_, generateErr := yamlconfig.GenerateSample(42)
+		require.Error(t, generateErr)
+	})
+
+	t.Run("Generate Sample With Inline Map", func(t *testing.T) {
+		sample, generateErr := yamlconfig.GenerateSample(&struct {
+			Name  string            `yaml:"name"`
+			Extra map[string]string `yaml:",inline"`
+		}{})
+		require.NoError(t, generateErr)
+		require.Equal(t, "name: \"\"  # string, required\n", string(sample))
+	})
+
+	t.Run("Generate Sample Of Recursive Type", func(t *testing.T) {
+		sample, generateErr := yamlconfig.GenerateSample(&TestConfigListNode{})
+		require.NoError(t, generateErr)
+		require.Equal(t, "name: \"\"  # string, required\n"+
+			"# next: {}  # *yamlconfig_test.TestConfigListNode, optional\n"+
+			"# children: []  # []yamlconfig_test.TestConfigListNode, optional\n", string(sample))
+	})
+}
@@ -0,0 +1,53 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// schemas holds the types registered with RegisterSchema.
+var schemas = struct {
+	sync.RWMutex
+	m map[string]reflect.Type
+}{m: map[string]reflect.Type{}}
+
+// RegisterSchema registers the type of prototype under name, so content can be
+// validated against it with ValidateAgainst by callers that do not hold the
+// type. The prototype may be a struct or map, or a pointer to one, and only
+// its type is kept. Registering a name again replaces the earlier type.
+//
+// Example:
+//
+//	yamlconfig.RegisterSchema("billing", BillingConfig{})
+func RegisterSchema(name string, prototype interface{}) {
+	schemas.Lock()
+	defer schemas.Unlock()
+
+	schemas.m[name] = derefType(reflect.TypeOf(prototype))
+}
+
+// ValidateAgainst decodes data into a fresh value of the type registered under
+// name with RegisterSchema and validates it, in the same way as ValidateBytes.
+//
+// Example:
+//
+//	if err := yamlconfig.ValidateAgainst(r.URL.Query().Get("schema"), body); err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	}
+func ValidateAgainst(name string, data []byte, opts ...Option) error {
+	schemas.RLock()
+	typ, ok := schemas.m[name]
+	schemas.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("unknown schema %q", name)
+	}
+
+	config := reflect.New(typ)
+	if typ.Kind() == reflect.Map {
+		config.Elem().Set(reflect.MakeMap(typ))
+	}
+
+	return ValidateBytes(data, config.Interface(), opts...)
+}
@@ -0,0 +1,37 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemas(t *testing.T) {
+	yamlconfig.RegisterSchema("test-struct", TestConfigStruct{})
+	yamlconfig.RegisterSchema("test-services", &map[string]TestServiceConfig{})
+
+	t.Run("Validate Against Registered Struct", func(t *testing.T) {
+		require.NoError(t, yamlconfig.ValidateAgainst("test-struct",
+			[]byte("string: test\nint: 1\nbool: true\nslice: [a]\nunit: 1\nfloat: 1.0\nstruct:\n  string: test\n")))
+
+		require.EqualError(t, yamlconfig.ValidateAgainst("test-struct", []byte("string: test\n")),
+			"failed to load the config: int: missing required config item")
+	})
+
+	t.Run("Validate Against Registered Map", func(t *testing.T) {
+		require.NoError(t, yamlconfig.ValidateAgainst("test-services", []byte("api:\n  host: a\n")))
+
+		require.EqualError(t, yamlconfig.ValidateAgainst("test-services", []byte("api:\n  port: 80\n")),
+			"failed to load the config: api.host: missing required config item")
+	})
+
+	t.Run("Validate Against Fresh Value", func(t *testing.T) {
+		require.NoError(t, yamlconfig.ValidateAgainst("test-services", []byte("api:\n  host: a\n")))
+		require.Error(t, yamlconfig.ValidateAgainst("test-services", []byte("web:\n  port: 80\n")))
+	})
+
+	t.Run("Unknown Schema", func(t *testing.T) {
+		require.EqualError(t, yamlconfig.ValidateAgainst("missing", []byte("{}\n")), "unknown schema \"missing\"")
+	})
+}
@@ -0,0 +1,163 @@
+package yamlconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadConfigSearch looks for a configuration file with the given name in each
+// directory in turn, such as /etc/app, then the user's config directory, then
+// the working directory, and loads the first one found in the same way as
+// LoadConfig. With WithSearchMergeAll every file found is deep-merged
+// instead, with files in directories listed earlier taking precedence, and
+// the merged configuration is validated once.
+//
+// Parameters:
+//
+// name: The file name of the configuration file, such as "config.yml".
+// dirs: The directories to search, in order of precedence.
+// config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
+//
+// Returns:
+// error: An error listing every path searched if no file was found, or an
+// error if the configuration could not be loaded, decoded or validated.
+//
+// Example:
+//
+// err := yamlconfig.LoadConfigSearch("config.yml", []string{"/etc/app", home, "."}, &cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func LoadConfigSearch(name string, dirs []string, config interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	return o.logResult(name, loadConfigSearch(name, dirs, config, o))
+}
+
+// WithSearchMergeAll makes LoadConfigSearch deep-merge every configuration
+// file it finds rather than loading only the first.
+func WithSearchMergeAll() Option {
+	return func(o *options) {
+		o.searchMergeAll = true
+	}
+}
+
+// loadConfigSearch performs the work of LoadConfigSearch using already
+// resolved options.
+func loadConfigSearch(name string, dirs []string, config interface{}, o *options) error {
+	searched := make([]string, 0, len(dirs))
+
+	var found []string
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		searched = append(searched, path)
+
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			if errors.Is(statErr, fs.ErrNotExist) {
+				continue
+			}
+
+			return fmt.Errorf("failed to load config file: %w", statErr)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		found = append(found, path)
+
+		if !o.searchMergeAll {
+			break
+		}
+	}
+
+	if len(found) == 0 {
+		return fmt.Errorf("failed to load config file: %s not found, searched: %s", name, strings.Join(searched, ", "))
+	}
+
+	if len(found) == 1 {
+		return loadConfig(found[0], config, o)
+	}
+
+	// Merge the lowest precedence file first so earlier directories win
+	paths := make([]string, len(found))
+	for i, path := range found {
+		paths[len(found)-1-i] = path
+	}
+
+	data, mergeErr := mergeFiles(paths, config, o, func(string) bool { return false })
+	if mergeErr != nil {
+		return mergeErr
+	}
+
+	return decodeConfig(data, found[0], config, o)
+}
+
+// LoadConfigFirst tries each configuration file in turn, such as
+// ./config.yml then /etc/app/config.yml, and loads the first one that exists
+// in the same way as LoadConfig. Files that do not exist are skipped, but a
+// file that exists and fails to decode or validate is reported rather than
+// falling through to the next one.
+//
+// Parameters:
+//
+// paths: The configuration files to try, in order of precedence.
+// config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
+//
+// Returns:
+// string: The path of the file that was loaded, or "" if none exists.
+// error: An error listing every path tried if none exists, which matches
+// fs.ErrNotExist, or an error if the file found could not be loaded, decoded
+// or validated.
+//
+// Example:
+//
+// path, err := yamlconfig.LoadConfigFirst([]string{"config.yml", "/etc/app/config.yml"}, &cfg)
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// log.Printf("loaded config from %s", path)
+func LoadConfigFirst(paths []string, config interface{}, opts ...Option) (string, error) {
+	o := newOptions(opts)
+
+	path, err := loadConfigFirst(paths, config, o)
+	if path == "" {
+		return "", o.logResult(strings.Join(paths, ", "), err)
+	}
+
+	return path, o.logResult(path, err)
+}
+
+// loadConfigFirst performs the work of LoadConfigFirst using already resolved
+// options.
+func loadConfigFirst(paths []string, config interface{}, o *options) (string, error) {
+	for _, path := range paths {
+		info, statErr := os.Stat(path)
+		if statErr != nil {
+			if errors.Is(statErr, fs.ErrNotExist) {
+				continue
+			}
+
+			return path, fmt.Errorf("failed to load config file: %w", statErr)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		return path, loadConfig(path, config, o)
+	}
+
+	return "", fmt.Errorf("failed to load config file: no config file found, tried: %s: %w", strings.Join(paths, ", "), fs.ErrNotExist)
+}
@@ -0,0 +1,92 @@
+package yamlconfig_test
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigSearch struct {
+	Server struct {
+		Address string `yaml:"address"`
+		Port    int    `yaml:"port" yamlconfig:"omitempty"`
+	} `yaml:"server"`
+	Database struct {
+		User     string `yaml:"user" yamlconfig:"omitempty"`
+		Password string `yaml:"password"`
+		Database string `yaml:"database"`
+	} `yaml:"database"`
+}
+
+func TestLoadConfigSearch(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"etc/app.yml":  "server:\n  address: etc\n  port: 80\ndatabase:\n  user: etc\n",
+		"home/app.yml": "server:\n  address: home\ndatabase:\n  password: secret\n  database: app\n",
+		"empty/.keep":  "",
+	})
+	etc, home, empty := filepath.Join(dir, "etc"), filepath.Join(dir, "home"), filepath.Join(dir, "empty")
+
+	t.Run("First Found", func(t *testing.T) {
+		cfg := TestConfigSearch{}
+		require.NoError(t, yamlconfig.LoadConfigSearch("app.yml", []string{empty, home, etc}, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect)))
+		require.Equal(t, "home", cfg.Server.Address)
+		require.Equal(t, 0, cfg.Server.Port)
+	})
+
+	t.Run("Merge All Found", func(t *testing.T) {
+		cfg := TestConfigSearch{}
+		require.NoError(t, yamlconfig.LoadConfigSearch("app.yml", []string{empty, home, etc}, &cfg, yamlconfig.WithSearchMergeAll()))
+		require.Equal(t, "home", cfg.Server.Address)
+		require.Equal(t, 80, cfg.Server.Port)
+		require.Equal(t, "etc", cfg.Database.User)
+		require.Equal(t, "secret", cfg.Database.Password)
+	})
+
+	t.Run("Merge All Validate Each File", func(t *testing.T) {
+		cfg := TestConfigSearch{}
+		loadConfigErr := yamlconfig.LoadConfigSearch("app.yml", []string{empty, home, etc}, &cfg,
+			yamlconfig.WithSearchMergeAll(), yamlconfig.WithValidateEachFile(), yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, filepath.Join(etc, "app.yml")+": failed to load the config: "+
+			"database.password: missing required config item; database.database: missing required config item")
+	})
+
+	t.Run("None Found", func(t *testing.T) {
+		loadConfigErr := yamlconfig.LoadConfigSearch("missing.yml", []string{etc, home}, &TestConfigSearch{})
+		require.EqualError(t, loadConfigErr, "failed to load config file: missing.yml not found, searched: "+
+			filepath.Join(etc, "missing.yml")+", "+filepath.Join(home, "missing.yml"))
+	})
+}
+
+func TestLoadConfigFirst(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"etc/app.yml":     "server:\n  address: etc\ndatabase:\n  password: secret\n  database: app\n",
+		"invalid/app.yml": "server:\n  address: invalid\n",
+	})
+	local, etc, invalid := filepath.Join(dir, "app.yml"), filepath.Join(dir, "etc", "app.yml"), filepath.Join(dir, "invalid", "app.yml")
+
+	t.Run("First Existing Loaded", func(t *testing.T) {
+		cfg := TestConfigSearch{}
+		path, loadConfigErr := yamlconfig.LoadConfigFirst([]string{local, filepath.Join(dir, "etc"), etc, invalid}, &cfg)
+		require.NoError(t, loadConfigErr)
+		require.Equal(t, etc, path)
+		require.Equal(t, "etc", cfg.Server.Address)
+	})
+
+	t.Run("Existing File Errors Reported", func(t *testing.T) {
+		cfg := TestConfigSearch{}
+		path, loadConfigErr := yamlconfig.LoadConfigFirst([]string{local, invalid, etc}, &cfg)
+		require.Equal(t, invalid, path)
+		require.EqualError(t, loadConfigErr, "failed to load the config: database: missing required config item")
+	})
+
+	t.Run("None Exist", func(t *testing.T) {
+		path, loadConfigErr := yamlconfig.LoadConfigFirst([]string{local, filepath.Join(dir, "missing.yml")}, &TestConfigSearch{})
+		require.Empty(t, path)
+		require.ErrorIs(t, loadConfigErr, fs.ErrNotExist)
+		require.EqualError(t, loadConfigErr, "failed to load config file: no config file found, tried: "+
+			local+", "+filepath.Join(dir, "missing.yml")+": file does not exist")
+	})
+}
@@ -0,0 +1,78 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RequireSections checks that the configuration file at path has every listed
+// top-level section, without decoding or validating the sections themselves.
+// It is a quick structural check for modular configs, to run before deeper
+// per-section validation.
+//
+// Parameters:
+//
+// path: The path to the configuration file, or "-" for standard input.
+// sections: The top-level keys that must be present.
+//
+// Returns:
+// error: An error listing every missing section, or nil if all are present.
+//
+// Example:
+//
+// err := yamlconfig.RequireSections("config.yml", []string{"logging", "server", "database"})
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func RequireSections(path string, sections []string) error {
+	data, fileErr := readConfigFile(path)
+	if fileErr != nil {
+		return fmt.Errorf("failed to load config file: %w", fileErr)
+	}
+
+	var doc yaml.Node
+	if yamlUnmarshalErr := yaml.Unmarshal(data, &doc); yamlUnmarshalErr != nil {
+		return decodeError(data, yamlUnmarshalErr)
+	}
+
+	// An empty file has no document, so every section is missing
+	var root *yaml.Node
+	if len(doc.Content) > 0 {
+		root = resolveNode(&doc)
+		if root.Kind != yaml.MappingNode {
+			return fmt.Errorf("failed to decode config file: top level is not a mapping")
+		}
+	}
+
+	var missing []string
+
+	for _, section := range sections {
+		if !hasKey(root, section) {
+			missing = append(missing, section)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required sections: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// hasKey reports whether the mapping node holds the key, whatever its value.
+func hasKey(node *yaml.Node, key string) bool {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireSections(t *testing.T) {
+	t.Run("All Sections Present", func(t *testing.T) {
+		path := writeTempConfig(t, "logging:\n  level: info\nserver:\ndatabase:\n  user: app\n")
+
+		require.NoError(t, yamlconfig.RequireSections(path, []string{"logging", "server", "database"}))
+	})
+
+	t.Run("Missing Sections Listed", func(t *testing.T) {
+		path := writeTempConfig(t, "server:\n  port: 80\n")
+
+		require.EqualError(t, yamlconfig.RequireSections(path, []string{"logging", "server", "database"}),
+			"missing required sections: logging, database")
+	})
+
+	t.Run("Empty File", func(t *testing.T) {
+		path := writeTempConfig(t, "")
+
+		require.EqualError(t, yamlconfig.RequireSections(path, []string{"server"}), "missing required sections: server")
+	})
+
+	t.Run("Top Level Not A Mapping", func(t *testing.T) {
+		path := writeTempConfig(t, "- server\n")
+
+		require.ErrorContains(t, yamlconfig.RequireSections(path, []string{"server"}), "top level is not a mapping")
+	})
+}
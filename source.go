@@ -0,0 +1,125 @@
+package yamlconfig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Source supplies the raw YAML content of a configuration. Implement it to
+// load configuration from stores such as etcd or Consul with LoadConfigFrom.
+type Source interface {
+	// Read returns the complete YAML content of the configuration.
+	Read() ([]byte, error)
+}
+
+// FileSource reads the configuration file at the path it holds, or standard
+// input when the path is "-". Its path is used to resolve !include tags and,
+// with WithResolvePaths, relative paths, in the same way as LoadConfig. Other
+// sources resolve !include tags against the working directory and leave
+// relative paths unchanged.
+type FileSource string
+
+// Read returns the content of the file with any byte order mark removed.
+func (s FileSource) Read() ([]byte, error) {
+	return readConfigFile(string(s))
+}
+
+// BytesSource holds YAML content that is already in memory.
+type BytesSource []byte
+
+// Read returns the content held by the source.
+func (s BytesSource) Read() ([]byte, error) {
+	return s, nil
+}
+
+// ReaderSource reads YAML content from an io.Reader until EOF.
+type ReaderSource struct {
+	Reader io.Reader
+}
+
+// Read returns everything read from the reader.
+func (s ReaderSource) Read() ([]byte, error) {
+	return io.ReadAll(s.Reader)
+}
+
+// HTTPSource fetches YAML content with a GET request to URL. A response with a
+// status other than 2xx is an error.
+type HTTPSource struct {
+	URL string
+	// Client sends the request. When nil http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Read fetches the URL and returns the response body.
+func (s HTTPSource) Read() ([]byte, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, reqErr := http.NewRequestWithContext(context.Background(), http.MethodGet, s.URL, nil)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	resp, respErr := client.Do(req)
+	if respErr != nil {
+		return nil, respErr
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %s fetching %s", resp.Status, s.URL)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// LoadConfigFrom reads the configuration from the source and decodes and
+// validates it into the provided struct pointer, in the same way as
+// LoadConfig. LoadConfig(path, ...) is the same as
+// LoadConfigFrom(FileSource(path), ...).
+//
+// Example:
+//
+//	src := yamlconfig.HTTPSource{URL: "https://config.internal/app.yml"}
+//	if err := yamlconfig.LoadConfigFrom(src, &cfg); err != nil {
+//	    log.Fatal(err)
+//	}
+func LoadConfigFrom(src Source, config interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	return o.logResult(sourcePath(src), loadSource(src, config, o))
+}
+
+// loadSource performs the work of LoadConfigFrom using already resolved
+// options.
+func loadSource(src Source, config interface{}, o *options) error {
+	if configErr := checkConfigPointer(config); configErr != nil {
+		return configErr
+	}
+
+	data, readErr := src.Read()
+	if readErr != nil {
+		return fmt.Errorf("failed to load config file: %w", readErr)
+	}
+
+	path := sourcePath(src)
+
+	o.logger(LoadEvent{Phase: PhaseOpened, Path: path, Message: "opened config file"})
+	o.addSource(path)
+
+	return decodeConfig(data, path, config, o)
+}
+
+// sourcePath returns the path of the file a source reads, or "" when the
+// source does not read a file.
+func sourcePath(src Source) string {
+	if path, ok := src.(FileSource); ok {
+		return string(path)
+	}
+
+	return ""
+}
@@ -0,0 +1,75 @@
+package yamlconfig_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestFailingSource struct{}
+
+func (TestFailingSource) Read() ([]byte, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func TestSources(t *testing.T) {
+	t.Run("File Source", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		path := writeTempConfig(t, "string: file\n")
+
+		require.NoError(t, yamlconfig.LoadConfigFrom(yamlconfig.FileSource(path), &cfg))
+		require.Equal(t, "file", cfg.String)
+	})
+
+	t.Run("Bytes Source", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+
+		require.NoError(t, yamlconfig.LoadConfigFrom(yamlconfig.BytesSource("string: bytes\n"), &cfg))
+		require.Equal(t, "bytes", cfg.String)
+	})
+
+	t.Run("Reader Source", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+		src := yamlconfig.ReaderSource{Reader: strings.NewReader("string: reader\n")}
+
+		require.NoError(t, yamlconfig.LoadConfigFrom(src, &cfg))
+		require.Equal(t, "reader", cfg.String)
+	})
+
+	t.Run("HTTP Source", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/app.yml" {
+				http.NotFound(w, r)
+				return
+			}
+
+			_, _ = w.Write([]byte("string: http\n"))
+		}))
+		defer server.Close()
+
+		cfg := TestConfigEmpty{}
+		require.NoError(t, yamlconfig.LoadConfigFrom(yamlconfig.HTTPSource{URL: server.URL + "/app.yml"}, &cfg))
+		require.Equal(t, "http", cfg.String)
+
+		loadConfigErr := yamlconfig.LoadConfigFrom(yamlconfig.HTTPSource{URL: server.URL + "/missing.yml", Client: server.Client()}, &cfg)
+		require.EqualError(t, loadConfigErr, "failed to load config file: unexpected status 404 Not Found fetching "+server.URL+"/missing.yml")
+	})
+
+	t.Run("Custom Source Error", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+
+		require.EqualError(t, yamlconfig.LoadConfigFrom(TestFailingSource{}, &cfg), "failed to load config file: store unavailable")
+	})
+
+	t.Run("Source Content Validated", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+
+		require.EqualError(t, yamlconfig.LoadConfigFrom(yamlconfig.BytesSource("int: 1\n"), &cfg),
+			"failed to load the config: string: missing required config item")
+	})
+}
@@ -0,0 +1,78 @@
+package yamlconfig
+
+import "path/filepath"
+
+// LoadConfigWithSources loads the configuration file in the same way as
+// LoadConfig and also returns the absolute path of every file that
+// contributed to it: the file itself, the files it includes with !include and
+// the files read by fromfile fields, in the order they were read. Watching
+// every one of them lets a change to any file trigger a reload. The files
+// read before an error are returned with it. Standard input is not listed.
+//
+// Parameters:
+//
+// path: The path to the configuration file, or "-" for standard input.
+// config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
+//
+// Returns:
+// []string: The files that contributed to the configuration.
+// error: An error if the configuration could not be loaded, decoded or
+// validated.
+//
+// Example:
+//
+//	sources, err := yamlconfig.LoadConfigWithSources("config.yml", &cfg)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, source := range sources {
+//	    watcher.Add(source)
+//	}
+func LoadConfigWithSources(path string, config interface{}, opts ...Option) ([]string, error) {
+	o := newOptions(opts)
+
+	var sources []string
+	WithSources(&sources)(o)
+
+	err := o.logResult(path, loadConfig(path, config, o))
+
+	return sources, err
+}
+
+// WithSources appends the absolute path of every file that contributes to the
+// configuration to the slice sources points to, as LoadConfigWithSources
+// returns them, in the order they were read. It works with every loader, so
+// the files merged by LoadConfigEnv and by LoadConfigSearch with
+// WithSearchMergeAll are listed too. Files read before an error are kept.
+//
+// Example:
+//
+//	var sources []string
+//	err := yamlconfig.LoadConfigEnv("config.yml", "prod", &cfg, yamlconfig.WithSources(&sources))
+func WithSources(sources *[]string) Option {
+	return func(o *options) {
+		o.sources = sources
+	}
+}
+
+// addSource records that the file at path contributed to the configuration,
+// for WithSources. Each file is listed once, by its absolute path.
+func (o *options) addSource(path string) {
+	if o.sources == nil || path == "" || path == stdinPath {
+		return
+	}
+
+	if abs, absErr := filepath.Abs(path); absErr == nil {
+		path = abs
+	}
+
+	for _, source := range *o.sources {
+		if source == path {
+			return
+		}
+	}
+
+	*o.sources = append(*o.sources, path)
+}
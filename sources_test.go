@@ -0,0 +1,131 @@
+package yamlconfig_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigSources struct {
+	Name     string `yaml:"name"`
+	Database struct {
+		User     string `yaml:"user"`
+		Password string `yaml:"password" yamlconfig:"fromfile,omitempty"`
+	} `yaml:"database"`
+	Hosts []string `yaml:"hosts" yamlconfig:"omitempty"`
+}
+
+func TestLoadConfigWithSources(t *testing.T) {
+	t.Run("Lists Included Files", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml":      "name: app\ndatabase: !include parts/db.yml\nhosts: !include parts/hosts.yml\n",
+			"parts/db.yml":    "user: app\n",
+			"parts/hosts.yml": "- a\n- b\n",
+		})
+
+		cfg := TestConfigSources{}
+		sources, err := yamlconfig.LoadConfigWithSources(filepath.Join(dir, "config.yml"), &cfg)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join(dir, "config.yml"),
+			filepath.Join(dir, "parts", "db.yml"),
+			filepath.Join(dir, "parts", "hosts.yml"),
+		}, sources)
+		require.Equal(t, "app", cfg.Database.User)
+	})
+
+	t.Run("Lists Fromfile Files", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"password": "secret\n",
+		})
+		passwordPath := filepath.Join(dir, "password")
+		path := writeTempConfig(t, "name: app\ndatabase:\n  user: app\n  password_file: "+passwordPath+"\n")
+
+		cfg := TestConfigSources{}
+		sources, err := yamlconfig.LoadConfigWithSources(path, &cfg)
+		require.NoError(t, err)
+		require.Equal(t, []string{path, passwordPath}, sources)
+		require.Equal(t, "secret", cfg.Database.Password)
+	})
+
+	t.Run("Lists Each File Once", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include db.yml\nhosts: [!include host.yml, !include host.yml]\n",
+			"db.yml":     "user: app\n",
+			"host.yml":   "a\n",
+		})
+
+		cfg := TestConfigSources{}
+		sources, err := yamlconfig.LoadConfigWithSources(filepath.Join(dir, "config.yml"), &cfg)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join(dir, "config.yml"),
+			filepath.Join(dir, "db.yml"),
+			filepath.Join(dir, "host.yml"),
+		}, sources)
+		require.Equal(t, []string{"a", "a"}, cfg.Hosts)
+	})
+
+	t.Run("Returns Files Read Before An Error", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml": "name: app\ndatabase: !include db.yml\nhosts: !include missing.yml\n",
+			"db.yml":     "user: app\n",
+		})
+
+		cfg := TestConfigSources{}
+		sources, err := yamlconfig.LoadConfigWithSources(filepath.Join(dir, "config.yml"), &cfg)
+		require.Error(t, err)
+		require.Equal(t, []string{
+			filepath.Join(dir, "config.yml"),
+			filepath.Join(dir, "db.yml"),
+		}, sources)
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		cfg := TestConfigSources{}
+		sources, err := yamlconfig.LoadConfigWithSources(filepath.Join(t.TempDir(), "missing.yml"), &cfg)
+		require.Error(t, err)
+		require.Empty(t, sources)
+	})
+
+	t.Run("Sources Option With Overlay", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"config.yml":      "name: app\ndatabase: !include db.yml\n",
+			"config.prod.yml": "name: prod\nhosts: !include hosts.yml\n",
+			"db.yml":          "user: app\n",
+			"hosts.yml":       "- a\n",
+		})
+
+		var sources []string
+
+		cfg := TestConfigSources{}
+		require.NoError(t, yamlconfig.LoadConfigEnv(filepath.Join(dir, "config.yml"), "prod", &cfg, yamlconfig.WithSources(&sources)))
+		require.Equal(t, []string{
+			filepath.Join(dir, "config.yml"),
+			filepath.Join(dir, "config.prod.yml"),
+			filepath.Join(dir, "db.yml"),
+			filepath.Join(dir, "hosts.yml"),
+		}, sources)
+		require.Equal(t, "prod", cfg.Name)
+	})
+
+	t.Run("Sources Option With Search Merge", func(t *testing.T) {
+		dir := writeIncludeFiles(t, map[string]string{
+			"etc/app.yml":  "name: app\ndatabase:\n  user: app\n",
+			"home/app.yml": "name: home\n",
+		})
+
+		var sources []string
+
+		cfg := TestConfigSources{}
+		require.NoError(t, yamlconfig.LoadConfigSearch("app.yml", []string{filepath.Join(dir, "home"), filepath.Join(dir, "etc")}, &cfg,
+			yamlconfig.WithSearchMergeAll(), yamlconfig.WithSources(&sources)))
+		require.Equal(t, []string{
+			filepath.Join(dir, "etc", "app.yml"),
+			filepath.Join(dir, "home", "app.yml"),
+		}, sources)
+		require.Equal(t, "home", cfg.Name)
+	})
+}
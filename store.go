@@ -0,0 +1,90 @@
+package yamlconfig
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Store holds the current configuration of type T for services that reload
+// their configuration while it is being read. Readers call Load to get the
+// current snapshot without locking, and Reload replaces the snapshot in a
+// single atomic swap once a new configuration has loaded and validated, so
+// readers never see a partially loaded or invalid configuration. Snapshots are
+// shared between readers and must not be modified. The zero value is an empty
+// Store using the default options.
+type Store[T any] struct {
+	current atomic.Pointer[T]
+	opts    []Option
+}
+
+// NewStore returns an empty Store that loads configuration files with the
+// provided options.
+//
+// Example:
+//
+//	store := yamlconfig.NewStore[Config](yamlconfig.WithErrorMode(yamlconfig.Collect))
+//	if err := store.Reload("config.yml"); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	cfg := store.Load()
+func NewStore[T any](opts ...Option) *Store[T] {
+	return &Store[T]{opts: opts}
+}
+
+// Load returns the current configuration snapshot, or nil if no configuration
+// has been loaded yet.
+func (s *Store[T]) Load() *T {
+	return s.current.Load()
+}
+
+// Reload loads and validates the configuration file at path into a new value,
+// in the same way as LoadConfig, and makes it the current snapshot. If loading
+// fails the current snapshot is kept and the error is returned.
+//
+// When T has fields tagged yamlconfig:"reloadable", only those fields may
+// change once a snapshot is current. If any other field changes, the current
+// snapshot is kept and a *RestartRequiredError listing the changed fields is
+// returned, so settings such as a listen port are never applied live.
+//
+// Fields tagged yamlconfig:"immutable", and every field within a struct so
+// tagged, may never change once a snapshot is current. If any does, the
+// current snapshot is kept and an *ImmutableChangedError listing the changed
+// fields is returned, whether or not T has reloadable fields.
+func (s *Store[T]) Reload(path string) error {
+	config := new(T)
+	if loadConfigErr := LoadConfig(path, config, s.opts...); loadConfigErr != nil {
+		return loadConfigErr
+	}
+
+	typ := reflect.TypeOf(config)
+	reloadable := hasReloadableFields(typ, map[reflect.Type]bool{})
+	immutable := hasTaggedFields(typ, "immutable", map[reflect.Type]bool{})
+
+	if !reloadable && !immutable {
+		s.current.Store(config)
+
+		return nil
+	}
+
+	// Compare against the snapshot being replaced, trying again if another
+	// reload replaced it in the meantime
+	for {
+		current := s.current.Load()
+		if current != nil && immutable {
+			if paths := immutableChangedPaths(reflect.ValueOf(current).Elem(), reflect.ValueOf(config).Elem(), "", false); len(paths) > 0 {
+				return &ImmutableChangedError{Paths: paths}
+			}
+		}
+
+		if current != nil && reloadable {
+			if paths := restartRequiredPaths(reflect.ValueOf(current).Elem(), reflect.ValueOf(config).Elem(), ""); len(paths) > 0 {
+				return &RestartRequiredError{Paths: paths}
+			}
+		}
+
+		if s.current.CompareAndSwap(current, config) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package yamlconfig_test
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("Reload Swaps Snapshot", func(t *testing.T) {
+		store := yamlconfig.NewStore[TestConfigEmpty]()
+		require.Nil(t, store.Load())
+
+		path := writeTempConfig(t, "string: first\n")
+		require.NoError(t, store.Reload(path))
+
+		first := store.Load()
+		require.Equal(t, "first", first.String)
+
+		require.NoError(t, os.WriteFile(path, []byte("string: second\n"), 0o600))
+		require.NoError(t, store.Reload(path))
+		require.Equal(t, "second", store.Load().String)
+		require.Equal(t, "first", first.String)
+	})
+
+	t.Run("Failed Reload Keeps Snapshot", func(t *testing.T) {
+		var store yamlconfig.Store[TestConfigEmpty]
+
+		path := writeTempConfig(t, "string: valid\n")
+		require.NoError(t, store.Reload(path))
+
+		require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+		require.ErrorContains(t, store.Reload(path), "string: missing required config item")
+		require.Equal(t, "valid", store.Load().String)
+	})
+
+	t.Run("Concurrent Readers", func(t *testing.T) {
+		store := yamlconfig.NewStore[TestConfigEmpty]()
+		path := writeTempConfig(t, "string: value\n")
+		require.NoError(t, store.Reload(path))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				require.Equal(t, "value", store.Load().String)
+			}()
+
+			go func() {
+				defer wg.Done()
+				require.NoError(t, store.Reload(path))
+			}()
+		}
+
+		wg.Wait()
+	})
+
+	t.Run("Reload Refuses Non-Reloadable Changes", func(t *testing.T) {
+		type config struct {
+			Listen struct {
+				Host string `yaml:"host"`
+				Port int    `yaml:"port"`
+			} `yaml:"listen"`
+			LogLevel string            `yaml:"log_level" yamlconfig:"reloadable"`
+			Limits   map[string]string `yaml:"limits" yamlconfig:"omitempty,reloadable"`
+		}
+
+		store := yamlconfig.NewStore[config]()
+		path := writeTempConfig(t, "listen:\n  host: a\n  port: 80\nlog_level: info\n")
+		require.NoError(t, store.Reload(path))
+
+		require.NoError(t, os.WriteFile(path, []byte("listen:\n  host: a\n  port: 80\nlog_level: debug\nlimits:\n  cpu: '1'\n"), 0o600))
+		require.NoError(t, store.Reload(path))
+		require.Equal(t, "debug", store.Load().LogLevel)
+
+		require.NoError(t, os.WriteFile(path, []byte("listen:\n  host: b\n  port: 8080\nlog_level: warn\n"), 0o600))
+
+		reloadErr := store.Reload(path)
+		require.EqualError(t, reloadErr, "config items require a restart to change: listen.host, listen.port")
+
+		var restartErr *yamlconfig.RestartRequiredError
+		require.ErrorAs(t, reloadErr, &restartErr)
+		require.Equal(t, []string{"listen.host", "listen.port"}, restartErr.Paths)
+		require.Equal(t, "debug", store.Load().LogLevel)
+	})
+
+	t.Run("Reload Refuses Immutable Changes", func(t *testing.T) {
+		type config struct {
+			NodeID  string `yaml:"node_id" yamlconfig:"immutable"`
+			Cluster struct {
+				Name   string `yaml:"name"`
+				Region string `yaml:"region"`
+			} `yaml:"cluster" yamlconfig:"immutable"`
+			LogLevel string `yaml:"log_level"`
+		}
+
+		store := yamlconfig.NewStore[config]()
+		path := writeTempConfig(t, "node_id: n1\ncluster:\n  name: main\n  region: eu\nlog_level: info\n")
+		require.NoError(t, store.Reload(path))
+
+		require.NoError(t, os.WriteFile(path, []byte("node_id: n1\ncluster:\n  name: main\n  region: eu\nlog_level: debug\n"), 0o600))
+		require.NoError(t, store.Reload(path))
+		require.Equal(t, "debug", store.Load().LogLevel)
+
+		require.NoError(t, os.WriteFile(path, []byte("node_id: n2\ncluster:\n  name: main\n  region: us\nlog_level: warn\n"), 0o600))
+
+		reloadErr := store.Reload(path)
+		require.EqualError(t, reloadErr, "immutable config items cannot change: node_id, cluster.region")
+
+		var immutableErr *yamlconfig.ImmutableChangedError
+		require.ErrorAs(t, reloadErr, &immutableErr)
+		require.Equal(t, []string{"node_id", "cluster.region"}, immutableErr.Paths)
+		require.Equal(t, "n1", store.Load().NodeID)
+		require.Equal(t, "debug", store.Load().LogLevel)
+	})
+
+	t.Run("Reload Refuses Immutable Changes To Reloadable Types", func(t *testing.T) {
+		type config struct {
+			NodeID   string `yaml:"node_id" yamlconfig:"immutable"`
+			Port     int    `yaml:"port"`
+			LogLevel string `yaml:"log_level" yamlconfig:"reloadable"`
+		}
+
+		store := yamlconfig.NewStore[config]()
+		path := writeTempConfig(t, "node_id: n1\nport: 80\nlog_level: info\n")
+		require.NoError(t, store.Reload(path))
+
+		require.NoError(t, os.WriteFile(path, []byte("node_id: n2\nport: 8080\nlog_level: info\n"), 0o600))
+		require.EqualError(t, store.Reload(path), "immutable config items cannot change: node_id")
+
+		require.NoError(t, os.WriteFile(path, []byte("node_id: n1\nport: 8080\nlog_level: info\n"), 0o600))
+		require.EqualError(t, store.Reload(path), "config items require a restart to change: port")
+	})
+}
@@ -0,0 +1,122 @@
+package yamlconfig
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithStrictTypes rejects scalars whose YAML type does not match the kind of
+// the field they are decoded into, instead of letting the decoder convert
+// them. A quoted "8080" or an unquoted 8080 for a string field, or a float
+// for an int field, fails loading with its line, path, YAML tag and the
+// expected kind. Integers are accepted for float fields, null is accepted
+// everywhere, and fields of types that decode themselves, such as
+// time.Duration and ByteSize, are left untouched. Quoted values retagged by
+// WithCoerceStrings count as the type they were coerced to.
+func WithStrictTypes() Option {
+	return func(o *options) {
+		o.strictTypes = true
+	}
+}
+
+// textUnmarshalerType is the reflect.Type of the encoding.TextUnmarshaler
+// interface, which the decoder uses for string scalars.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// strictTags maps the kinds checked by WithStrictTypes to the YAML tags their
+// scalars may have.
+var strictTags = map[reflect.Kind][]string{
+	reflect.String:  {"!!str"},
+	reflect.Bool:    {"!!bool"},
+	reflect.Int:     {"!!int"},
+	reflect.Int8:    {"!!int"},
+	reflect.Int16:   {"!!int"},
+	reflect.Int32:   {"!!int"},
+	reflect.Int64:   {"!!int"},
+	reflect.Uint:    {"!!int"},
+	reflect.Uint8:   {"!!int"},
+	reflect.Uint16:  {"!!int"},
+	reflect.Uint32:  {"!!int"},
+	reflect.Uint64:  {"!!int"},
+	reflect.Float32: {"!!float", "!!int"},
+	reflect.Float64: {"!!float", "!!int"},
+}
+
+// checkStrictTypes checks that each scalar of node decoded into a field of
+// typ has a YAML tag matching the field's kind. The path is the dotted path
+// of node within the document.
+func checkStrictTypes(node *yaml.Node, typ reflect.Type, path string) error {
+	node = resolveNode(node)
+	typ = derefType(typ)
+
+	if node == nil || typ == nodeType || typ == durationType || decodesItself(typ) {
+		return nil
+	}
+
+	switch node.Kind { //nolint:exhaustive // Document and alias nodes are resolved above
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+
+			var valueType reflect.Type
+
+			switch typ.Kind() { //nolint:exhaustive // Only structs and maps hold mappings
+			case reflect.Struct:
+				field, ok := structFieldByKey(typ, key)
+				if !ok {
+					continue
+				}
+
+				valueType = field.Type
+			case reflect.Map:
+				valueType = typ.Elem()
+			default:
+				return nil
+			}
+
+			if err := checkStrictTypes(node.Content[i+1], valueType, joinPath(path, key)); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
+			return nil
+		}
+
+		for i, item := range node.Content {
+			if err := checkStrictTypes(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		tags, ok := strictTags[typ.Kind()]
+		if !ok || node.ShortTag() == "!!null" {
+			return nil
+		}
+
+		for _, tag := range tags {
+			if node.ShortTag() == tag {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("line %d: %s has YAML tag %s, expected %s", node.Line, path, node.ShortTag(), typ.Kind())
+	}
+
+	return nil
+}
+
+// decodesItself reports whether values of typ are decoded by their own
+// UnmarshalYAML or UnmarshalText method.
+func decodesItself(typ reflect.Type) bool {
+	for _, iface := range []reflect.Type{unmarshalerType, textUnmarshalerType} {
+		if typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface) {
+			return true
+		}
+	}
+
+	return false
+}
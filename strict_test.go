@@ -0,0 +1,86 @@
+package yamlconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigStrict struct {
+	Name    string              `yaml:"name"`
+	Port    int                 `yaml:"port" yamlconfig:"omitempty"`
+	Ratio   float64             `yaml:"ratio" yamlconfig:"omitempty"`
+	Debug   bool                `yaml:"debug" yamlconfig:"omitempty"`
+	Timeout time.Duration       `yaml:"timeout" yamlconfig:"omitempty"`
+	Size    yamlconfig.ByteSize `yaml:"size" yamlconfig:"omitempty"`
+	Labels  map[string]string   `yaml:"labels" yamlconfig:"omitempty"`
+	Hosts   []struct {
+		Address string `yaml:"address"`
+	} `yaml:"hosts" yamlconfig:"omitempty"`
+}
+
+func TestStrictTypes(t *testing.T) {
+	t.Run("Matching Types", func(t *testing.T) {
+		cfg := TestConfigStrict{}
+		path := writeTempConfig(t, "name: app\nport: 8080\nratio: 1\ndebug: true\ntimeout: 5s\nsize: 10MB\nlabels:\n  tier: web\nhosts:\n  - address: a\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithStrictTypes()))
+		require.Equal(t, 8080, cfg.Port)
+		require.InDelta(t, 1.0, cfg.Ratio, 0)
+		require.Equal(t, 5*time.Second, cfg.Timeout)
+	})
+
+	t.Run("Mismatched Types", func(t *testing.T) {
+		tests := map[string]string{
+			"name: 8080\n":                           "line 1: name has YAML tag !!int, expected string",
+			"name: app\nport: \"8080\"\n":            "line 2: port has YAML tag !!str, expected int",
+			"name: app\nport: 1.5\n":                 "line 2: port has YAML tag !!float, expected int",
+			"name: app\ndebug: \"true\"\n":           "line 2: debug has YAML tag !!str, expected bool",
+			"name: app\nlabels:\n  tier: 1\n":        "line 3: labels.tier has YAML tag !!int, expected string",
+			"name: app\nhosts:\n  - address: true\n": "line 3: hosts[0].address has YAML tag !!bool, expected string",
+		}
+
+		for content, expected := range tests {
+			cfg := TestConfigStrict{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithStrictTypes()), "failed to decode config file: "+expected)
+		}
+	})
+
+	t.Run("Null Values", func(t *testing.T) {
+		cfg := TestConfigStrict{}
+		path := writeTempConfig(t, "name: app\nport: ~\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithStrictTypes()))
+	})
+
+	t.Run("Coerced Strings", func(t *testing.T) {
+		cfg := TestConfigStrict{}
+		path := writeTempConfig(t, "name: app\nport: \"8080\"\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithCoerceStrings(), yamlconfig.WithStrictTypes()))
+		require.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("Off By Default", func(t *testing.T) {
+		cfg := TestConfigStrict{}
+		path := writeTempConfig(t, "name: 8080\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "8080", cfg.Name)
+	})
+
+	t.Run("Inline Map Holds Undeclared Keys", func(t *testing.T) {
+		cfg := struct {
+			Port  int               `yaml:"port"`
+			Extra map[string]string `yaml:",inline"`
+		}{}
+		path := writeTempConfig(t, "port: 8080\nregion: eu\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithStrictTypes()))
+		require.Equal(t, map[string]string{"region": "eu"}, cfg.Extra)
+	})
+}
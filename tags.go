@@ -0,0 +1,39 @@
+package yamlconfig
+
+import "strings"
+
+// fieldTag holds the options parsed from a field's yamlconfig struct tag. The
+// tag is a comma separated list of options, each either a bare name such as
+// "omitempty" or a name=value pair such as "requiredkeys=region zone".
+type fieldTag map[string]string
+
+// parseTag parses the value of a yamlconfig struct tag.
+func parseTag(tag string) fieldTag {
+	t := fieldTag{}
+
+	for _, item := range strings.Split(tag, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(item, "=")
+		t[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return t
+}
+
+// has reports whether the tag contains the named option.
+func (t fieldTag) has(name string) bool {
+	_, ok := t[name]
+
+	return ok
+}
+
+// get returns the value of the named option and whether it was present.
+func (t fieldTag) get(name string) (string, bool) {
+	value, ok := t[name]
+
+	return value, ok
+}
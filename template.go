@@ -0,0 +1,63 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// WithTemplate renders the configuration file as a Go text/template before
+// it is decoded, so sections can be conditional or computed. The data map is
+// the template's dot, and a reference to a key it does not hold is an error.
+// Besides the built-in functions, templates can call env to read an
+// environment variable and default to fall back when a value is empty:
+//
+//	replicas: {{ .replicas }}
+//	region: {{ env "REGION" | default "eu-west-1" }}
+//	{{ if eq .stage "prod" }}
+//	tls: true
+//	{{ end }}
+//
+// Included files are not rendered.
+func WithTemplate(data map[string]interface{}) Option {
+	return func(o *options) {
+		o.template = true
+		o.templateData = data
+	}
+}
+
+// templateFuncs holds the functions available to templates rendered by
+// WithTemplate, in addition to the text/template built-ins.
+var templateFuncs = template.FuncMap{
+	"env": os.Getenv,
+	"default": func(fallback, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return fallback
+		}
+
+		return value
+	},
+}
+
+// renderTemplate executes data as a template with the given template data.
+// The path names the template in error messages.
+func renderTemplate(data []byte, path string, templateData map[string]interface{}) ([]byte, error) {
+	name := "config"
+	if path != "" {
+		name = filepath.Base(path)
+	}
+
+	tmpl, parseErr := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(string(data))
+	if parseErr != nil {
+		return nil, fmt.Errorf("failed to parse config template: %w", parseErr)
+	}
+
+	var rendered bytes.Buffer
+	if executeErr := tmpl.Execute(&rendered, templateData); executeErr != nil {
+		return nil, fmt.Errorf("failed to render config template: %w", executeErr)
+	}
+
+	return rendered.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigTemplate struct {
+	Name     string `yaml:"name"`
+	Replicas int    `yaml:"replicas"`
+	Region   string `yaml:"region"`
+	TLS      bool   `yaml:"tls" yamlconfig:"omitempty"`
+}
+
+func TestTemplate(t *testing.T) {
+	t.Run("Template Rendered Before Decode", func(t *testing.T) {
+		t.Setenv("TEST_TEMPLATE_REGION", "us-east-1")
+
+		cfg := TestConfigTemplate{}
+		path := writeTempConfig(t, "name: {{ .name }}\nreplicas: {{ .replicas }}\nregion: {{ env \"TEST_TEMPLATE_REGION\" }}\n"+
+			"{{ if eq .stage \"prod\" }}tls: true\n{{ end }}")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithTemplate(map[string]interface{}{
+			"name": "api", "replicas": 3, "stage": "prod",
+		})))
+		require.Equal(t, TestConfigTemplate{Name: "api", Replicas: 3, Region: "us-east-1", TLS: true}, cfg)
+	})
+
+	t.Run("Default For Unset Variable", func(t *testing.T) {
+		cfg := TestConfigTemplate{}
+		path := writeTempConfig(t, "name: api\nreplicas: 1\nregion: {{ env \"TEST_TEMPLATE_UNSET\" | default \"eu-west-1\" }}\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithTemplate(nil)))
+		require.Equal(t, "eu-west-1", cfg.Region)
+		require.False(t, cfg.TLS)
+	})
+
+	t.Run("Missing Data Key", func(t *testing.T) {
+		cfg := TestConfigTemplate{}
+		path := writeTempConfig(t, "name: {{ .nmae }}\nreplicas: 1\nregion: eu\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithTemplate(map[string]interface{}{"name": "api"}))
+		require.ErrorContains(t, loadConfigErr, "failed to render config template: template: ")
+		require.ErrorContains(t, loadConfigErr, `:1:9: executing "`)
+		require.ErrorContains(t, loadConfigErr, `map has no entry for key "nmae"`)
+	})
+
+	t.Run("Invalid Template", func(t *testing.T) {
+		cfg := TestConfigTemplate{}
+		path := writeTempConfig(t, "name: api\nreplicas: {{ mul .replicas 2 }}\nregion: eu\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithTemplate(nil))
+		require.ErrorContains(t, loadConfigErr, "failed to parse config template: template: ")
+		require.ErrorContains(t, loadConfigErr, `function "mul" not defined`)
+	})
+
+	t.Run("Not Rendered Without Option", func(t *testing.T) {
+		cfg := TestConfigTemplate{}
+		path := writeTempConfig(t, "name: \"{{ .name }}\"\nreplicas: 1\nregion: eu\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "{{ .name }}", cfg.Name)
+	})
+}
@@ -0,0 +1,79 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// transforms lists the yamlconfig tag options that rewrite string values,
+// in the order they are applied.
+var transforms = []struct {
+	name string
+	fn   func(string) string
+}{
+	{"trim", strings.TrimSpace},
+	{"lower", strings.ToLower},
+	{"upper", strings.ToUpper},
+}
+
+// applyTransforms rewrites the values of fields tagged yamlconfig:"trim",
+// "lower" or "upper". String fields are rewritten directly, string slices
+// element by element and string maps value by value. Fields of other types
+// carrying a transform are reported to errs. Fields tagged
+// yamlconfig:"canonical" are rewritten to the casing of the value listed in
+// their oneofci tag.
+func applyTransforms(val reflect.Value, errs *errorList) error {
+	return walkFields(val, "", func(field reflect.Value, typ reflect.StructField, path string) error {
+		tag := parseTag(typ.Tag.Get("yamlconfig"))
+
+		for _, transform := range transforms {
+			if !tag.has(transform.name) {
+				continue
+			}
+
+			if !transformField(field, transform.fn) {
+				return errs.add(fmt.Errorf("%s is only supported on string, string slice and string map fields: %s", transform.name, path))
+			}
+		}
+
+		// Rewrite a oneofci value to the casing listed in the tag. A value
+		// that is not listed is left for validation to report
+		if tag.has("canonical") {
+			allowed, ok := tag.get("oneofci")
+			if !ok || field.Kind() != reflect.String {
+				return errs.add(fmt.Errorf("canonical is only supported on string fields tagged oneofci: %s", path))
+			}
+
+			if candidate, ok := canonicalCase(field.String(), strings.Fields(allowed)); ok {
+				field.SetString(candidate)
+			}
+		}
+
+		return nil
+	})
+}
+
+// transformField applies fn to the string value, or string elements, of the
+// field. It reports false if the field holds no strings.
+func transformField(field reflect.Value, fn func(string) string) bool {
+	switch {
+	case field.Kind() == reflect.String:
+		field.SetString(fn(field.String()))
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < field.Len(); i++ {
+			field.Index(i).SetString(fn(field.Index(i).String()))
+		}
+	case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.String:
+		iter := field.MapRange()
+		for iter.Next() {
+			value := reflect.New(field.Type().Elem()).Elem()
+			value.SetString(fn(iter.Value().String()))
+			field.SetMapIndex(iter.Key(), value)
+		}
+	default:
+		return false
+	}
+
+	return true
+}
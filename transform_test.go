@@ -0,0 +1,39 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestConfigTransforms struct {
+	Name    string            `yaml:"name" yamlconfig:"trim"`
+	Level   string            `yaml:"level" yamlconfig:"trim,lower,oneof=debug info"`
+	Hosts   []string          `yaml:"hosts" yamlconfig:"trim,lower"`
+	Headers map[string]string `yaml:"headers" yamlconfig:"trim,upper"`
+}
+
+type TestConfigInvalidTransform struct {
+	Port int `yaml:"port" yamlconfig:"trim"`
+}
+
+func TestTransforms(t *testing.T) {
+	t.Run("Transforms Applied Before Validation", func(t *testing.T) {
+		cfg := TestConfigTransforms{}
+		path := writeTempConfig(t, "name: \"  app \"\nlevel: \" INFO \"\nhosts: [\" A.example.com\", \"b.EXAMPLE.com \"]\nheaders:\n  X-Env: \" prod \"\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "app", cfg.Name)
+		require.Equal(t, "info", cfg.Level)
+		require.Equal(t, []string{"a.example.com", "b.example.com"}, cfg.Hosts)
+		require.Equal(t, map[string]string{"X-Env": "PROD"}, cfg.Headers)
+	})
+
+	t.Run("Transform On Unsupported Field", func(t *testing.T) {
+		cfg := TestConfigInvalidTransform{}
+		path := writeTempConfig(t, "port: 80\n")
+
+		require.ErrorContains(t, yamlconfig.LoadConfig(path, &cfg), "trim is only supported on string, string slice and string map fields: port")
+	})
+}
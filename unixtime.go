@@ -0,0 +1,43 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// convertUnixTime rewrites a timestamp decoded into a field of type typ tagged
+// yamlconfig:"unixtime" into the matching number of seconds since the Unix
+// epoch, so the decoder reads it as an integer. Integers and nulls are left
+// for the decoder.
+func convertUnixTime(node *yaml.Node, typ reflect.Type, path string) error {
+	typ = derefType(typ)
+
+	switch typ.Kind() { //nolint:exhaustive // Only signed integers hold unix times
+	case reflect.Int, reflect.Int32, reflect.Int64:
+	default:
+		return fmt.Errorf("unixtime is only supported on int, int32 and int64 fields: %s", path)
+	}
+
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!int" || node.Tag == "!!null" {
+		return nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, node.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: value %q is not an RFC3339 timestamp for %s", node.Line, node.Value, path)
+	}
+
+	if reflect.Zero(typ).OverflowInt(parsed.Unix()) {
+		return fmt.Errorf("line %d: value %q overflows %s for %s", node.Line, node.Value, typ, path)
+	}
+
+	node.Tag = "!!int"
+	node.Value = strconv.FormatInt(parsed.Unix(), 10)
+	node.Style = 0
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+type TestCertificate struct {
+	Name    string `yaml:"name"`
+	Expires int64  `yaml:"expires" yamlconfig:"unixtime"`
+}
+
+type TestConfigUnixTime struct {
+	Issued int               `yaml:"issued" yamlconfig:"unixtime"`
+	Certs  []TestCertificate `yaml:"certs" yamlconfig:"omitempty"`
+}
+
+func TestUnixTime(t *testing.T) {
+	t.Run("RFC3339 Timestamps Converted", func(t *testing.T) {
+		cfg := TestConfigUnixTime{}
+		path := writeTempConfig(t, "issued: 2024-01-02T03:04:05Z\ncerts:\n  - name: api\n    expires: \"2025-01-01T00:00:00+01:00\"\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, 1704164645, cfg.Issued)
+		require.Equal(t, int64(1735686000), cfg.Certs[0].Expires)
+	})
+
+	t.Run("Integers Left Unchanged", func(t *testing.T) {
+		cfg := TestConfigUnixTime{}
+		path := writeTempConfig(t, "issued: 1704164645\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, 1704164645, cfg.Issued)
+	})
+
+	t.Run("Invalid Timestamp", func(t *testing.T) {
+		cfg := TestConfigUnixTime{}
+		path := writeTempConfig(t, "issued: 2024-01-02T03:04:05Z\ncerts:\n  - name: api\n    expires: next year\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg),
+			"failed to decode config file: line 4: value \"next year\" is not an RFC3339 timestamp for certs[0].expires")
+	})
+
+	t.Run("Unsupported Field Type", func(t *testing.T) {
+		cfg := struct {
+			Issued string `yaml:"issued" yamlconfig:"unixtime"`
+		}{}
+		path := writeTempConfig(t, "issued: 2024-01-02T03:04:05Z\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg),
+			"failed to decode config file: unixtime is only supported on int, int32 and int64 fields: issued")
+	})
+
+	t.Run("Unix Time Beside Inline Map", func(t *testing.T) {
+		cfg := struct {
+			Issued int64             `yaml:"issued" yamlconfig:"unixtime"`
+			Extra  map[string]string `yaml:",inline"`
+		}{}
+		path := writeTempConfig(t, "region: eu\nissued: 1970-01-01T00:01:00Z\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, int64(60), cfg.Issued)
+		require.Equal(t, map[string]string{"region": "eu"}, cfg.Extra)
+	})
+}
@@ -0,0 +1,64 @@
+package yamlconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RequireVersion reads the configuration file at path and checks that the
+// version held under the key field is one of the supported versions, without
+// decoding the rest of the file. Call it before loading so a config written
+// for an incompatible version of the application fails with a clear message
+// rather than a confusing decode or validation error.
+//
+// Parameters:
+//
+// path: The path to the configuration file, or "-" for standard input.
+// field: The dotted path of the version key, such as "version".
+// supported: The versions the application supports, compared as strings.
+//
+// Returns:
+// error: An error if the file could not be read or parsed, the version key is
+// missing or the version is not supported.
+//
+// Example:
+//
+//	if err := yamlconfig.RequireVersion("config.yml", "version", []string{"2", "3"}); err != nil {
+//	    log.Fatal(err)
+//	}
+func RequireVersion(path, field string, supported []string) error {
+	data, fileErr := readConfigFile(path)
+	if fileErr != nil {
+		return fmt.Errorf("failed to load config file: %w", fileErr)
+	}
+
+	data = stripBOM(data)
+
+	var doc yaml.Node
+	if yamlUnmarshalErr := yaml.Unmarshal(data, &doc); yamlUnmarshalErr != nil {
+		return decodeError(data, yamlUnmarshalErr)
+	}
+
+	node := &doc
+	for _, key := range strings.Split(field, ".") {
+		if node = mappingValue(node, key); node == nil {
+			return fmt.Errorf("%s: config version is missing, supported versions are: %s", field, strings.Join(supported, ", "))
+		}
+	}
+
+	if node.Kind != yaml.ScalarNode || node.ShortTag() == "!!null" {
+		return fmt.Errorf("line %d: %s: config version must be a single value, supported versions are: %s",
+			node.Line, field, strings.Join(supported, ", "))
+	}
+
+	for _, version := range supported {
+		if node.Value == version {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("line %d: %s: config version %q is not supported, supported versions are: %s",
+		node.Line, field, node.Value, strings.Join(supported, ", "))
+}
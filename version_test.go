@@ -0,0 +1,44 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireVersion(t *testing.T) {
+	supported := []string{"2", "3"}
+
+	t.Run("Supported Version", func(t *testing.T) {
+		require.NoError(t, yamlconfig.RequireVersion(writeTempConfig(t, "version: 2\nport: 80\n"), "version", supported))
+		require.NoError(t, yamlconfig.RequireVersion(writeTempConfig(t, "meta:\n  schema: \"3\"\n"), "meta.schema", supported))
+	})
+
+	t.Run("Unsupported Version", func(t *testing.T) {
+		path := writeTempConfig(t, "name: app\nversion: 1\n")
+
+		require.EqualError(t, yamlconfig.RequireVersion(path, "version", supported),
+			"line 2: version: config version \"1\" is not supported, supported versions are: 2, 3")
+	})
+
+	t.Run("Missing Version", func(t *testing.T) {
+		path := writeTempConfig(t, "name: app\n")
+
+		require.EqualError(t, yamlconfig.RequireVersion(path, "version", supported),
+			"version: config version is missing, supported versions are: 2, 3")
+		require.EqualError(t, yamlconfig.RequireVersion(path, "meta.schema", supported),
+			"meta.schema: config version is missing, supported versions are: 2, 3")
+	})
+
+	t.Run("Version Not A Single Value", func(t *testing.T) {
+		path := writeTempConfig(t, "version:\n  - 2\n")
+
+		require.EqualError(t, yamlconfig.RequireVersion(path, "version", supported),
+			"line 2: version: config version must be a single value, supported versions are: 2, 3")
+	})
+
+	t.Run("Unreadable File", func(t *testing.T) {
+		require.ErrorContains(t, yamlconfig.RequireVersion("nonexistent.yml", "version", supported), "failed to load config file")
+	})
+}
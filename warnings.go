@@ -0,0 +1,177 @@
+package yamlconfig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Warning describes a problem found while loading that did not stop the load,
+// such as a deprecated key or an alias. Warnings are passed to the logger as
+// PhaseWarning events and returned by LoadConfigWarnings.
+type Warning struct {
+	// Line is the line of the document the warning refers to, or 0 if it
+	// is not known.
+	Line int
+	// Path is the dotted path of the config item, or "" if there is none.
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+// String formats the warning as "line 3: server.port: message", leaving out
+// the line and path when they are not known.
+func (w Warning) String() string {
+	message := w.Message
+	if w.Path != "" {
+		message = w.Path + ": " + message
+	}
+
+	if w.Line > 0 {
+		message = fmt.Sprintf("line %d: %s", w.Line, message)
+	}
+
+	return message
+}
+
+// LoadConfigWarnings loads the configuration file in the same way as
+// LoadConfig and also returns the warnings found while loading, so operators
+// get feedback on problems that do not fail the load. Warnings cover keys of
+// fields tagged yamlconfig:"deprecated", aliases, duplicate keys ignored
+// because of WithFirstKeyWins and decode problems tolerated by
+// WithDecodeWarnings. Warnings found before an error are returned with it.
+//
+// Example:
+//
+//	warnings, err := yamlconfig.LoadConfigWarnings("config.yml", &cfg)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, w := range warnings {
+//	    log.Printf("config warning: %s", w)
+//	}
+func LoadConfigWarnings(path string, config interface{}, opts ...Option) ([]Warning, error) {
+	o := newOptions(opts)
+
+	var warnings []Warning
+	o.warnings = &warnings
+
+	err := o.logResult(path, loadConfig(path, config, o))
+
+	return warnings, err
+}
+
+// warn passes the warning to the logger as a PhaseWarning event and keeps it
+// for LoadConfigWarnings. The path is that of the file being loaded.
+func (o *options) warn(path string, w Warning) {
+	o.logger(LoadEvent{Phase: PhaseWarning, Path: path, Message: w.String()})
+
+	if o.warnings != nil {
+		*o.warnings = append(*o.warnings, w)
+	}
+}
+
+// warnDocument reports the aliases used in the document and the keys present
+// for fields of typ tagged yamlconfig:"deprecated". The path is that of the
+// file being loaded.
+func (o *options) warnDocument(path string, doc *yaml.Node, typ reflect.Type) {
+	collectAliases(doc, "", func(alias *yaml.Node, aliasPath string) {
+		o.warn(path, Warning{Line: alias.Line, Path: aliasPath, Message: "alias *" + alias.Value + " used"})
+	})
+
+	if !hasTaggedFields(typ, "deprecated", map[reflect.Type]bool{}) {
+		return
+	}
+
+	_ = rewriteTaggedNodes(doc, typ, "", "deprecated", func(node *yaml.Node, _ reflect.Type, itemPath string) error {
+		o.warn(path, Warning{Line: node.Line, Path: itemPath, Message: "config item is deprecated"})
+
+		return nil
+	})
+}
+
+// collectAliases calls fn with every alias node in the tree and its dotted
+// path. Aliases are not followed.
+func collectAliases(node *yaml.Node, path string, fn func(alias *yaml.Node, path string)) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		fn(node, path)
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectAliases(child, path, fn)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectAliases(node.Content[i+1], joinPath(path, node.Content[i].Value), fn)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			collectAliases(item, fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case yaml.ScalarNode:
+	}
+}
+
+// WithDecodeWarnings tolerates decode problems that leave every known field
+// decoded correctly, such as keys the struct has no field for when unknown
+// fields are rejected through WithDecoder. Each one is passed to the logger
+// as a PhaseWarning event instead of failing the load, which suits forward
+// compatible configs that may carry a few newer keys. Values that cannot be
+// decoded into their field's type are still errors.
+func WithDecodeWarnings() Option {
+	return func(o *options) {
+		o.decodeWarnings = true
+	}
+}
+
+// handleDecodeError sorts the problems of a decode error. Problems tolerated
+// by WithDecodeWarnings are logged as warnings, and in Collect mode the rest
+// of a yaml.TypeError is kept in errs, since the decoder has still decoded
+// everything else. The error that should stop loading is returned, or nil if
+// loading can carry on.
+func (o *options) handleDecodeError(path string, err error, errs *errorList) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	var hard []string
+
+	for _, message := range typeErr.Errors {
+		if o.decodeWarnings && !strings.Contains(message, "cannot unmarshal") {
+			o.warn(path, Warning{Message: message})
+
+			continue
+		}
+
+		hard = append(hard, message)
+	}
+
+	if len(hard) == 0 {
+		return nil
+	}
+
+	if o.errorMode != Collect {
+		return &yaml.TypeError{Errors: hard}
+	}
+
+	for _, message := range hard {
+		errs.errs = append(errs.errs, typeError(message))
+	}
+
+	return nil
+}
+
+// typeError is a value of the wrong type collected in Collect mode. It keeps
+// the decoder's message and lets load outcomes tell it apart from validation
+// errors.
+type typeError string
+
+// Error implements the error interface.
+func (e typeError) Error() string {
+	return string(e)
+}
@@ -0,0 +1,111 @@
+package yamlconfig_test
+
+import (
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type TestConfigWarnings struct {
+	Host    string `yaml:"host"`
+	Timeout string `yaml:"timeout" yamlconfig:"omitempty,deprecated"`
+	Server  struct {
+		Port int `yaml:"port"`
+	} `yaml:"server"`
+}
+
+func TestWarnings(t *testing.T) {
+	t.Run("No Warnings", func(t *testing.T) {
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "host: a\nserver:\n  port: 80\n")
+
+		warnings, loadConfigErr := yamlconfig.LoadConfigWarnings(path, &cfg)
+		require.NoError(t, loadConfigErr)
+		require.Empty(t, warnings)
+	})
+
+	t.Run("Deprecated Keys", func(t *testing.T) {
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "host: a\ntimeout: 5s\nserver:\n  port: 80\n")
+
+		warnings, loadConfigErr := yamlconfig.LoadConfigWarnings(path, &cfg)
+		require.NoError(t, loadConfigErr)
+		require.Equal(t, "5s", cfg.Timeout)
+		require.Equal(t, []yamlconfig.Warning{{Line: 2, Path: "timeout", Message: "config item is deprecated"}}, warnings)
+		require.Equal(t, "line 2: timeout: config item is deprecated", warnings[0].String())
+	})
+
+	t.Run("Aliases Used", func(t *testing.T) {
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "host: &name a\nserver:\n  port: 80\nextra: *name\n")
+
+		warnings, loadConfigErr := yamlconfig.LoadConfigWarnings(path, &cfg)
+		require.NoError(t, loadConfigErr)
+		require.Equal(t, []yamlconfig.Warning{{Line: 4, Path: "extra", Message: "alias *name used"}}, warnings)
+	})
+
+	t.Run("Duplicate Keys Under First Key Wins", func(t *testing.T) {
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "host: a\nserver:\n  port: 80\n  port: 81\nhost: b\n")
+
+		warnings, loadConfigErr := yamlconfig.LoadConfigWarnings(path, &cfg, yamlconfig.WithFirstKeyWins())
+		require.NoError(t, loadConfigErr)
+		require.Equal(t, "a", cfg.Host)
+		require.Equal(t, 80, cfg.Server.Port)
+		require.Equal(t, []yamlconfig.Warning{
+			{Line: 5, Path: "host", Message: "duplicate key ignored, the first occurrence is used"},
+			{Line: 4, Path: "server.port", Message: "duplicate key ignored, the first occurrence is used"},
+		}, warnings)
+	})
+
+	t.Run("Decode Warnings", func(t *testing.T) {
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "host: a\nserver:\n  port: 80\nunknown: true\n")
+
+		warnings, loadConfigErr := yamlconfig.LoadConfigWarnings(path, &cfg,
+			yamlconfig.WithDecoder(func(d *yaml.Decoder) { d.KnownFields(true) }),
+			yamlconfig.WithDecodeWarnings())
+		require.NoError(t, loadConfigErr)
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0].String(), "line 4: field unknown not found")
+	})
+
+	t.Run("Warnings Returned With Error", func(t *testing.T) {
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "timeout: 5s\nserver:\n  port: 80\n")
+
+		warnings, loadConfigErr := yamlconfig.LoadConfigWarnings(path, &cfg)
+		require.EqualError(t, loadConfigErr, "failed to load the config: host: missing required config item")
+		require.Len(t, warnings, 1)
+	})
+
+	t.Run("Warnings Logged", func(t *testing.T) {
+		var messages []string
+		logger := yamlconfig.WithLogger(func(e yamlconfig.LoadEvent) {
+			if e.Phase == yamlconfig.PhaseWarning {
+				messages = append(messages, e.Message)
+			}
+		})
+
+		cfg := TestConfigWarnings{}
+		path := writeTempConfig(t, "host: a\ntimeout: 5s\nserver:\n  port: 80\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg, logger))
+		require.Equal(t, []string{"line 2: timeout: config item is deprecated"}, messages)
+	})
+
+	t.Run("Deprecated Field Beside Inline Map", func(t *testing.T) {
+		cfg := struct {
+			Timeout string            `yaml:"timeout" yamlconfig:"omitempty,deprecated"`
+			Extra   map[string]string `yaml:",inline"`
+		}{}
+		path := writeTempConfig(t, "region: eu\ntimeout: 5s\n")
+
+		warnings, loadErr := yamlconfig.LoadConfigWarnings(path, &cfg)
+		require.NoError(t, loadErr)
+		require.Equal(t, []yamlconfig.Warning{{Line: 2, Path: "timeout", Message: "config item is deprecated"}}, warnings)
+		require.Equal(t, map[string]string{"region": "eu"}, cfg.Extra)
+	})
+}
@@ -0,0 +1,197 @@
+package yamlconfig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultIndent is the indentation width used by yaml.v3 when encoding.
+const defaultIndent = 4
+
+// WriteOption configures how a configuration is written as YAML.
+type WriteOption func(*writeOptions)
+
+// writeOptions holds the settings collected from WriteOption values.
+type writeOptions struct {
+	indent         int
+	flowSequences  bool
+	filePermission os.FileMode
+	redactSecrets  bool
+}
+
+// newWriteOptions applies the provided WriteOption values on top of the
+// defaults and returns the resulting settings.
+func newWriteOptions(opts []WriteOption) *writeOptions {
+	wo := &writeOptions{
+		indent:         defaultIndent,
+		filePermission: 0o600,
+	}
+
+	for _, opt := range opts {
+		opt(wo)
+	}
+
+	return wo
+}
+
+// WithIndent sets the number of spaces used for each level of indentation.
+// The default is 4.
+func WithIndent(n int) WriteOption {
+	return func(wo *writeOptions) {
+		wo.indent = n
+	}
+}
+
+// WithFlowSequences writes every sequence in flow style, such as [a, b, c],
+// instead of one item per line, for more compact output.
+func WithFlowSequences() WriteOption {
+	return func(wo *writeOptions) {
+		wo.flowSequences = true
+	}
+}
+
+// WithRedactSecrets writes the value of every field tagged
+// yamlconfig:"secret" as "REDACTED", for output that is logged or shown to
+// people.
+func WithRedactSecrets() WriteOption {
+	return func(wo *writeOptions) {
+		wo.redactSecrets = true
+	}
+}
+
+// WriteConfig encodes the configuration as YAML and writes it to w.
+//
+// Parameters:
+//
+// w: The writer to write the YAML document to.
+// config: The configuration struct, or a pointer to it.
+// opts: Optional settings that change how the YAML is formatted.
+//
+// Returns:
+// error: An error if the configuration could not be encoded or written.
+//
+// Example:
+//
+// err := yamlconfig.WriteConfig(os.Stdout, &cfg, yamlconfig.WithIndent(2))
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func WriteConfig(w io.Writer, config interface{}, opts ...WriteOption) error {
+	wo := newWriteOptions(opts)
+
+	var node yaml.Node
+	if encodeErr := node.Encode(config); encodeErr != nil {
+		return fmt.Errorf("failed to encode config: %w", encodeErr)
+	}
+
+	if wo.redactSecrets {
+		redactTagged(reflect.ValueOf(config), &node)
+	}
+
+	return writeNode(w, &node, wo)
+}
+
+// EffectiveConfig returns the configuration an application is running with as
+// YAML, after every layer has been applied: defaults, overlays, patches and
+// any changes made by AfterDecode hooks. It answers what configuration is
+// actually in use during an incident or a support request. Pass
+// WithRedactSecrets to hide the values of secret fields.
+//
+// Parameters:
+//
+// config: The configuration struct, or a pointer to it.
+// opts: Optional settings that change how the YAML is formatted.
+//
+// Returns:
+// []byte: The YAML document.
+// error: An error if the configuration could not be encoded.
+//
+// Example:
+//
+// out, err := yamlconfig.EffectiveConfig(&cfg, yamlconfig.WithRedactSecrets())
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func EffectiveConfig(config interface{}, opts ...WriteOption) ([]byte, error) {
+	var buf bytes.Buffer
+	if writeErr := WriteConfig(&buf, config, opts...); writeErr != nil {
+		return nil, writeErr
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SaveConfig encodes the configuration as YAML and writes it to the file at
+// path, creating or truncating it. New files are created with 0600
+// permissions since configuration often holds secrets.
+//
+// Parameters:
+//
+// path: The path of the file to write.
+// config: The configuration struct, or a pointer to it.
+// opts: Optional settings that change how the YAML is formatted.
+//
+// Returns:
+// error: An error if the configuration could not be encoded or the file could
+// not be written.
+//
+// Example:
+//
+// err := yamlconfig.SaveConfig("config.yml", &cfg, yamlconfig.WithIndent(2))
+//
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func SaveConfig(path string, config interface{}, opts ...WriteOption) error {
+	wo := newWriteOptions(opts)
+
+	var buf bytes.Buffer
+	if writeErr := WriteConfig(&buf, config, opts...); writeErr != nil {
+		return writeErr
+	}
+
+	if fileErr := os.WriteFile(path, buf.Bytes(), wo.filePermission); fileErr != nil {
+		return fmt.Errorf("failed to write config file: %w", fileErr)
+	}
+
+	return nil
+}
+
+// writeNode applies the formatting options to the node tree and writes it to
+// w as a YAML document.
+func writeNode(w io.Writer, node *yaml.Node, wo *writeOptions) error {
+	if wo.flowSequences {
+		setSequenceStyle(node, yaml.FlowStyle)
+	}
+
+	e := yaml.NewEncoder(w)
+	e.SetIndent(wo.indent)
+
+	if encodeErr := e.Encode(node); encodeErr != nil {
+		return fmt.Errorf("failed to encode config: %w", encodeErr)
+	}
+
+	if closeErr := e.Close(); closeErr != nil {
+		return fmt.Errorf("failed to encode config: %w", closeErr)
+	}
+
+	return nil
+}
+
+// setSequenceStyle sets the style of every sequence node in the tree.
+func setSequenceStyle(node *yaml.Node, style yaml.Style) {
+	if node.Kind == yaml.SequenceNode {
+		node.Style = style
+	}
+
+	for _, child := range node.Content {
+		setSequenceStyle(child, style)
+	}
+}
@@ -0,0 +1,80 @@
+package yamlconfig_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sculley/yamlconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteConfig(t *testing.T) {
+	cfg := TestConfigGet{Name: "app"}
+	cfg.Servers = append(cfg.Servers, struct {
+		Address string `yaml:"address"`
+		Ports   []int  `yaml:"ports"`
+	}{Address: "a", Ports: []int{80, 443}})
+
+	t.Run("Write Config Default Indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, yamlconfig.WriteConfig(&buf, &cfg))
+
+		require.Equal(t, "name: app\nservers:\n    - address: a\n      ports:\n        - 80\n        - 443\nlabels: {}\ndatabase: null\n", buf.String())
+	})
+
+	t.Run("Write Config Indent And Flow Sequences", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, yamlconfig.WriteConfig(&buf, &cfg, yamlconfig.WithIndent(2), yamlconfig.WithFlowSequences()))
+
+		require.Equal(t, "name: app\nservers: [{address: a, ports: [80, 443]}]\nlabels: {}\ndatabase: null\n", buf.String())
+	})
+
+	t.Run("Save Config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, yamlconfig.SaveConfig(path, &TestConfigEmpty{String: "test"}, yamlconfig.WithIndent(2)))
+
+		data, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		require.Equal(t, "string: test\n", string(data))
+	})
+
+	t.Run("Effective Config After Defaults", func(t *testing.T) {
+		loaded := TestConfigDefaults{}
+		path := writeTempConfig(t, "name: app\nserver:\n  host: example.com\n")
+		require.NoError(t, yamlconfig.LoadConfig(path, &loaded))
+
+		out, effectiveErr := yamlconfig.EffectiveConfig(&loaded, yamlconfig.WithIndent(2), yamlconfig.WithFlowSequences())
+		require.NoError(t, effectiveErr)
+		require.Equal(t, "name: app\nport: 8080\ntimeout: 5s\nmethods: [GET, HEAD]\nserver:\n  host: example.com\n", string(out))
+	})
+
+	t.Run("Effective Config Redacted", func(t *testing.T) {
+		secret := TestConfigSecret{}
+		secret.Database.User = "app"
+		secret.Database.Password = "s3cret"
+
+		out, effectiveErr := yamlconfig.EffectiveConfig(&secret, yamlconfig.WithIndent(2), yamlconfig.WithRedactSecrets())
+		require.NoError(t, effectiveErr)
+		require.Equal(t, "database:\n  user: app\n  password: REDACTED\ncache:\n  password: \"\"\n", string(out))
+	})
+
+	t.Run("Effective Config Redacts Nested Secrets", func(t *testing.T) {
+		nested := TestConfigNestedSecrets{
+			Databases: map[string]TestConfigSecretUser{"main": {Name: "app", Password: "hunter2"}},
+			Users:     []TestConfigSecretUser{{Name: "ops", Password: "s3cret"}},
+		}
+
+		out, effectiveErr := yamlconfig.EffectiveConfig(&nested, yamlconfig.WithIndent(2), yamlconfig.WithFlowSequences(), yamlconfig.WithRedactSecrets())
+		require.NoError(t, effectiveErr)
+		require.Contains(t, string(out), "databases:\n  main:\n    name: app\n    password: REDACTED\n")
+		require.NotContains(t, string(out), "s3cret")
+
+		root := map[string]TestConfigSecretUser{"root": {Name: "root", Password: "rootpw"}}
+
+		out, effectiveErr = yamlconfig.EffectiveConfig(root, yamlconfig.WithIndent(2), yamlconfig.WithRedactSecrets())
+		require.NoError(t, effectiveErr)
+		require.Equal(t, "root:\n  name: root\n  password: REDACTED\n", string(out))
+	})
+}
@@ -1,20 +1,30 @@
 package yamlconfig
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // LoadConfig loads a YAML configuration file from the provided path and decodes it
 // into the provided struct pointer. It also validates the loaded configuration.
+// A path of "-" reads the configuration from standard input. The config may
+// also point to a map, such as map[string]ServiceConfig, in which case each
+// struct value is validated with its key in the path. Use LoadConfigFrom to
+// read the configuration from another Source.
 //
 // Parameters:
 //
-// path: The path to the configuration file.
+// path: The path to the configuration file, or "-" for standard input.
 // config: A pointer to the struct to decode the configuration into.
+// opts: Optional settings that change how the configuration is loaded.
 //
 // Returns:
 // error: An error if the configuration file could not be loaded or decoded.
@@ -27,70 +37,475 @@ import (
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func LoadConfig(path string, config interface{}) error {
-	// Open the configuration file
-	file, fileErr := os.Open(path)
-	if fileErr != nil {
-		return fmt.Errorf("failed to load config file: %w", fileErr)
+func LoadConfig(path string, config interface{}, opts ...Option) error {
+	o := newOptions(opts)
+
+	return o.logResult(path, loadConfig(path, config, o))
+}
+
+// loadConfig performs the work of LoadConfig using already resolved options.
+func loadConfig(path string, config interface{}, o *options) error {
+	return loadSource(FileSource(path), config, o)
+}
+
+// stdinPath is the path that makes the loader read from standard input.
+const stdinPath = "-"
+
+// readConfigFile reads the configuration file at path, or standard input when
+// path is "-". A leading UTF-8 byte order mark is removed.
+func readConfigFile(path string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if path == stdinPath {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	return stripBOM(data), err
+}
+
+// decodeConfig decodes the raw YAML content into the provided struct pointer,
+// runs the post-decode passes and validates the result. The path is only used
+// to describe the source in log events.
+func decodeConfig(data []byte, path string, config interface{}, o *options) error {
+	_, err := decodeDocument(data, path, config, o, nil)
+
+	return err
+}
+
+// decodeDocument does the work of decodeConfig and returns the document the
+// config was decoded from. When previous is the document of an earlier load
+// of the same config type, only the parts of the config that changed since
+// are validated, unless the config depends on more than its document.
+func decodeDocument(data []byte, path string, config interface{}, o *options, previous *yaml.Node) (*yaml.Node, error) {
+	if configErr := checkConfigPointer(config); configErr != nil {
+		return nil, configErr
+	}
+
+	data = stripBOM(data)
+
+	// Lint the file as it was written, before anything rewrites it
+	if len(o.lintRules) > 0 {
+		if lintErr := lint(data, o.lintRules); lintErr != nil {
+			return nil, lintErr
+		}
 	}
-	defer file.Close()
 
-	// Create a new YAML decoder for the file
-	d := yaml.NewDecoder(file)
+	// Render the file as a template before anything reads it as YAML
+	if o.template {
+		rendered, templateErr := renderTemplate(data, path, o.templateData)
+		if templateErr != nil {
+			return nil, templateErr
+		}
 
-	// Decode the YAML content into the provided struct pointer
+		data = rendered
+	}
+
+	// Reject directives before they reach the decoder when asked to
+	if o.rejectDirectives {
+		if directivesErr := checkDirectives(data); directivesErr != nil {
+			return nil, directivesErr
+		}
+	}
+
+	// Rewrite the document tree before decoding when an option needs to
+	if passes := o.documentPasses(data, path, reflect.TypeOf(config)); len(passes) > 0 {
+		rewritten, rewriteErr := rewriteDocument(data, passes)
+		if rewriteErr != nil {
+			return nil, rewriteErr
+		}
+
+		data = rewritten
+	}
+
+	// Errors from every pass are gathered here in Collect mode
+	v := newValidator(o)
+
+	// Create a new YAML decoder for the content
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	for _, fn := range o.decoderFuncs {
+		fn(d)
+	}
+
+	// Decode the YAML content into the provided struct pointer. Values of the
+	// wrong type leave the rest of the document decoded, so in Collect mode
+	// loading carries on and each one is reported
 	if yamlDecodeErr := d.Decode(config); yamlDecodeErr != nil {
-		return fmt.Errorf("failed to decode config file: %w", yamlDecodeErr)
+		if decodeErr := o.handleDecodeError(path, yamlDecodeErr, &v.errorList); decodeErr != nil {
+			return nil, decodeError(data, decodeErr)
+		}
+	}
+
+	// Parse the content into a node tree for the passes that need to know
+	// which keys were present in the document
+	var doc yaml.Node
+	if yamlNodeErr := yaml.Unmarshal(data, &doc); yamlNodeErr != nil {
+		return nil, fmt.Errorf("failed to decode config file: %w", yamlNodeErr)
+	}
+
+	o.warnDocument(path, &doc, reflect.TypeOf(config))
+
+	// Populate fields whose values are read from referenced files
+	if fromFileErr := applyFromFile(reflect.ValueOf(config), &doc, o.addSource, &v.errorList); fromFileErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", fromFileErr)
+	}
+
+	// Override fields from the environment variables named in their tags
+	if envErr := applyEnv(reflect.ValueOf(config), o.requireEnv, o.envListSeparator, o.envKeyValueSeparator, &v.errorList); envErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", envErr)
 	}
 
-	// Validate the loaded configuration
-	if validateConfigErr := validateConfig(config); validateConfigErr != nil {
-		return fmt.Errorf(("failed to load the config: %w"), validateConfigErr)
+	// Fill in empty fields that have a default value
+	if defaultsErr := applyDefaults(reflect.ValueOf(config), &doc, "", &v.errorList); defaultsErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", defaultsErr)
+	}
+
+	// Replace references to external values with the values they refer to
+	if resolveErr := applyResolvers(reflect.ValueOf(config), o.resolvers, &v.errorList); resolveErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", resolveErr)
+	}
+
+	// Normalise string values and map keys as requested by their tags
+	if transformsErr := applyTransforms(reflect.ValueOf(config), &v.errorList); transformsErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", transformsErr)
+	}
+
+	if lowerKeysErr := applyLowerKeys(reflect.ValueOf(config), o.lowerMapKeys, &v.errorList); lowerKeysErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", lowerKeysErr)
+	}
+
+	// Resolve relative paths against the directory of the config file
+	if o.resolvePaths && path != "" {
+		if resolvePathsErr := resolvePaths(reflect.ValueOf(config), filepath.Dir(path), &v.errorList); resolvePathsErr != nil {
+			return nil, fmt.Errorf("failed to load the config: %w", resolvePathsErr)
+		}
+	}
+
+	// Let the config types post-process themselves
+	if afterDecodeErr := applyAfterDecode(reflect.ValueOf(config), ""); afterDecodeErr != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", afterDecodeErr)
+	}
+
+	o.logger(LoadEvent{Phase: PhaseDecoded, Path: path, Message: "decoded config file"})
+
+	// Validate the loaded configuration, judging required fields by whether
+	// their key was present unless value-based checks were asked for
+	if !o.valueBasedRequired {
+		v.doc = &doc
+	}
+
+	// Skip the parts of the config that are unchanged since the previous
+	// load, unless resolved values may have changed without the document
+	if previous != nil && len(o.resolvers) == 0 && dependsOnDocumentOnly(reflect.TypeOf(config), map[reflect.Type]bool{}) {
+		if changes, ok := diffDocuments(previous, &doc); ok {
+			v.changes = changes
+		}
+	}
+
+	if validateConfigErr := v.validateConfig(config); validateConfigErr != nil {
+		return nil, fmt.Errorf(("failed to load the config: %w"), validateConfigErr)
+	}
+
+	o.logger(LoadEvent{
+		Phase:   PhaseValidated,
+		Path:    path,
+		Fields:  v.fields,
+		Message: fmt.Sprintf("validated %d config fields", v.fields),
+	})
+
+	if o.freeze {
+		freeze(config)
+	}
+
+	return &doc, nil
+}
+
+// checkConfigPointer returns an error unless config is a non-nil pointer to a
+// struct or map, which is checked before anything is read or decoded so a
+// mistaken argument is reported plainly rather than as a decoding failure.
+func checkConfigPointer(config interface{}) error {
+	val := reflect.ValueOf(config)
+	if val.Kind() != reflect.Ptr || val.IsNil() || (val.Elem().Kind() != reflect.Struct && val.Elem().Kind() != reflect.Map) {
+		return fmt.Errorf("config must be a non-nil pointer to a struct or map, got %T", config)
 	}
 
 	return nil
 }
 
+// decodeError wraps an error returned while decoding data. Tabs used for
+// indentation produce a cryptic syntax error, so the offending line is pointed
+// at instead.
+func decodeError(data []byte, err error) error {
+	if line := tabIndentedLine(data); line > 0 {
+		return fmt.Errorf("failed to decode config file: line %d: tabs are not allowed for indentation in YAML", line)
+	}
+
+	return fmt.Errorf("failed to decode config file: %w", err)
+}
+
+// validator holds the settings and state of a single validation run.
+type validator struct {
+	// allowZeroNumbers treats zero numeric values as set rather than empty.
+	allowZeroNumbers bool
+	// optionalPointers treats pointer fields as optional without omitempty.
+	optionalPointers bool
+	// relaxed holds the dotted paths of config items treated as optional
+	// without omitempty.
+	relaxed map[string]bool
+	// errorList decides whether validation stops at the first error and
+	// collects the errors found when it does not.
+	errorList
+	// verbose attaches the offending value to validation errors.
+	verbose bool
+	// fields counts the struct fields checked so far.
+	fields int
+	// doc is the decoded document, used to tell whether a field's key was
+	// present. Without it a field is missing whenever its value is empty.
+	doc *yaml.Node
+	// configValidators are called with the whole configuration once its
+	// fields have been checked.
+	configValidators []func(config interface{}) error
+	// changes limits validation to the structs that hold, or lie within, a
+	// changed config item. When nil every struct is validated.
+	changes changeSet
+}
+
+// newValidator returns a validator configured from the loader options.
+func newValidator(o *options) *validator {
+	return &validator{
+		allowZeroNumbers: o.allowZeroNumbers,
+		optionalPointers: o.optionalPointers,
+		relaxed:          o.relaxedRequired,
+		errorList:        errorList{mode: o.errorMode},
+		verbose:          o.verboseErrors,
+		configValidators: o.configValidators,
+	}
+}
+
+// isEmpty reports whether the field counts as empty for this validation run.
+// A nil pointer is empty, a pointer to any value is not, and a struct is
+// empty when every one of its fields is.
+func (v *validator) isEmpty(field reflect.Value) bool {
+	if v.allowZeroNumbers && isNumber(field) {
+		return false
+	}
+
+	switch field.Kind() { //nolint:exhaustive // Other kinds are handled by isEmpty
+	case reflect.Ptr:
+		return field.IsNil()
+	case reflect.Struct:
+		if field.Type() == nodeType {
+			return isNullNode(field)
+		}
+
+		for i := 0; i < field.NumField(); i++ {
+			if !v.isEmpty(field.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return isEmpty(field)
+}
+
+// isMemberSet reports whether the field stored under key in the struct decoded
+// from node counts as set for the relations and groups it belongs to. A field
+// is set unless it is empty, except that with allowZeroNumbers a zero number
+// is only set when its key is present in node, so a member left out of the
+// document is not mistaken for one written as 0.
+func (v *validator) isMemberSet(field reflect.Value, node *yaml.Node, key string) bool {
+	if v.allowZeroNumbers && v.doc != nil && isNumber(field) {
+		return !isEmpty(field) || hasKey(node, key)
+	}
+
+	return !v.isEmpty(field)
+}
+
+// fail records a validation error for the config item at path. In FailFast
+// mode the error is returned so validation stops, in Collect mode it is kept
+// and nil is returned so validation carries on. The value of fields tagged
+// yamlconfig:"secret" is masked in the message, and in verbose mode the
+// field's value is attached to the error.
+func (v *validator) fail(path string, field reflect.Value, tag fieldTag, message string) error {
+	err := &ValidationError{Path: path, Message: message}
+
+	if field.IsValid() {
+		value := fmt.Sprint(field)
+
+		switch {
+		case tag.has("secret"):
+			if value != "" {
+				err.Message = strings.ReplaceAll(err.Message, value, redactedValue)
+			}
+
+			value = redactedValue
+		case field.Kind() == reflect.String:
+			value = strconv.Quote(value)
+		}
+
+		if v.verbose {
+			err.Value = value
+		}
+	}
+
+	return v.add(err)
+}
+
 // validateConfig function checks if the provided configuration is valid. It
 // ensures that all required fields are present and non-empty.
-func validateConfig(config interface{}) error {
+func (v *validator) validateConfig(config interface{}) error {
 	val := reflect.ValueOf(config)
 
-	// Check if the config is a pointer and points to a struct
-	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
-		return fmt.Errorf("expected a pointer to a struct, please ensure the input is a struct pointer")
+	// Check if the config is a pointer and points to a struct or a map
+	if val.Kind() != reflect.Ptr || (val.Elem().Kind() != reflect.Struct && val.Elem().Kind() != reflect.Map) {
+		return fmt.Errorf("expected a pointer to a struct or map, please ensure the input is a struct or map pointer")
 	}
 
-	// Recursively validate the struct
-	return validateStruct(val.Elem())
+	// Recursively validate the struct, or each struct value of the map
+	if val.Elem().Kind() == reflect.Map {
+		if err := v.validateMapValues(val.Elem(), "", resolveNode(v.doc)); err != nil {
+			return err
+		}
+	} else if err := v.validateStruct(val.Elem(), "", resolveNode(v.doc)); err != nil {
+		return err
+	}
+
+	// Run the whole-config validators once the fields have been checked
+	for _, validate := range v.configValidators {
+		if err := v.add(validate(config)); err != nil {
+			return err
+		}
+	}
+
+	return v.err()
+}
+
+// validateMapValues validates each struct value of the map field under its
+// key. The path is the dotted path of the map and node is the mapping it was
+// decoded from, or nil if there is none.
+func (v *validator) validateMapValues(field reflect.Value, path string, node *yaml.Node) error {
+	return walkMapValues(field, func(key string, value reflect.Value) error {
+		if value = indirect(value); !value.IsValid() {
+			return nil
+		}
+
+		return v.validateStruct(value, joinPath(path, key), mappingValue(node, key))
+	})
 }
 
 // validateStruct function recursively validates a struct and its fields.
 // It checks if all required fields are present and non-empty.
 // A field is considered required if it does not have the yamlconfig tag "omitempty".
-func validateStruct(val reflect.Value) error {
+// Fields with a value are also checked against the rules listed in their tag.
+// The path is the dotted path of the struct within the configuration and node
+// is the mapping it was decoded from, or nil if there is none. A field whose
+// key is present in node counts as set even if its value is the zero value, so
+// it is not missing and its rules are checked.
+//
+// When only changes are validated, a struct that neither holds nor lies within
+// a changed item is skipped. Every field of a struct holding a changed item is
+// checked, so rules relating the changed field to its siblings still apply.
+func (v *validator) validateStruct(val reflect.Value, path string, node *yaml.Node) error {
+	if v.changes != nil && !v.changes.touches(path) {
+		return nil
+	}
+
+	// Members of relations and groups are judged by the keys of this struct
+	isMemberSet := func(field reflect.Value, key string) bool {
+		return v.isMemberSet(field, node, key)
+	}
+
 	for i := 0; i < val.NumField(); i++ {
 		field := val.Field(i)
 		typ := val.Type().Field(i)
+		v.fields++
+
+		// Inlined fields are not config items of their own. The fields of an
+		// inlined struct are checked as though they belonged to this struct,
+		// sharing its path and node, and an inlined map only holds the keys
+		// left over by the other fields
+		key, inline, _ := yamlKey(typ)
+		if inline {
+			if nested := indirect(field); nested.IsValid() && nested.Kind() == reflect.Struct {
+				if err := v.validateStruct(nested, path, node); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		fieldPath := joinPath(path, key)
+
+		// Check for the yamlconfig tag, pointer fields may be optional by
+		// convention and other fields relaxed by path instead
+		yamlConfigTag := parseTag(typ.Tag.Get("yamlconfig"))
+		isOmitEmpty := yamlConfigTag.has("omitempty") || (v.optionalPointers && field.Kind() == reflect.Ptr) || v.relaxed[fieldPath]
 
-		// Check for the yamlconfig tag
-		yamlConfigTag := typ.Tag.Get("yamlconfig")
-		isOmitEmpty := yamlConfigTag == "omitempty"
+		// Work out the node the field was decoded from. A field is set when it
+		// has a value or its key was present in the document, except that a
+		// base64 field is only set when it decoded to some bytes and a
+		// yaml.Node field when it holds something other than null
+		fieldNode := mappingValue(node, key)
+		isSet := !v.isEmpty(field) || (hasKey(node, key) && !yamlConfigTag.has("base64") && derefType(field.Type()) != nodeType)
+
+		// If the field is required (no omitempty) and not set, report an error
+		if !isOmitEmpty && !isSet {
+			if err := v.fail(fieldPath, reflect.Value{}, yamlConfigTag, "missing required config item"); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Check the rules that relate the field to its siblings
+		if relationErr := validateRelations(val, field, key, yamlConfigTag, isMemberSet); relationErr != nil {
+			if err := v.fail(fieldPath, field, yamlConfigTag, relationErr.Error()); err != nil {
+				return err
+			}
+		}
+
+		// Apply any validation rules from the tag to fields that are set
+		if isSet {
+			if ruleErr := validateRules(field, yamlConfigTag); ruleErr != nil {
+				if err := v.fail(fieldPath, field, yamlConfigTag, ruleErr.Error()); err != nil {
+					return err
+				}
+			}
+		}
 
-		// If the field is required (no omitempty) and empty, return an error
-		if !isOmitEmpty && isEmpty(field) {
-			return fmt.Errorf("missing required config item: %s", typ.Name)
+		// An optional section that is not set is not checked for its own
+		// required fields
+		if !isSet {
+			continue
 		}
 
-		// Recursively validate nested structs
-		if field.Kind() == reflect.Struct {
-			if err := validateStruct(field); err != nil {
+		// Recursively validate nested structs and the structs that non-nil
+		// pointers point to. A yaml.Node is kept raw to be decoded later, so
+		// its internals are not config items
+		if nested := indirect(field); nested.IsValid() && nested.Kind() == reflect.Struct && nested.Type() != nodeType {
+			if err := v.validateStruct(nested, fieldPath, fieldNode); err != nil {
 				return err
 			}
 		}
+
+		// Validate each struct value of a map under its key
+		if err := v.validateMapValues(field, fieldPath, fieldNode); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	// Check the groups of fields that must be set in combination, reporting
+	// each under its first member
+	return validateGroups(val, isMemberSet, func(key string, err error) error {
+		return v.fail(joinPath(path, key), reflect.Value{}, fieldTag{}, err.Error())
+	})
 }
 
 // isEmpty function checks if a value is empty. It is used to validate the
@@ -110,11 +525,56 @@ func isEmpty(v reflect.Value) bool {
 	case reflect.Bool:
 		return !v.Bool()
 	case reflect.Struct:
+		if v.Type() == nodeType {
+			return isNullNode(v)
+		}
+
+		// A struct is empty when every field is, counting nil pointers as
+		// empty, so a nested struct whose key is missing is not mistaken
+		// for one that was set
 		for i := 0; i < v.NumField(); i++ {
-			if !isEmpty(v.Field(i)) {
+			field := v.Field(i)
+			if field.Kind() == reflect.Ptr && field.IsNil() {
+				continue
+			}
+
+			if !isEmpty(field) {
 				return false
 			}
 		}
+
+		return true
+	}
+
+	return false
+}
+
+// nodeType is the type of yaml.Node. Fields of this type keep a section of the
+// document raw so it can be decoded later, once the type it holds is known.
+var nodeType = reflect.TypeOf(yaml.Node{})
+
+// isNullNode reports whether the yaml.Node v is empty: the zero Node left when
+// its key is missing, or an explicit null. The node is read field by field, as
+// v may not be addressable or exported.
+func isNullNode(v reflect.Value) bool {
+	node := yaml.Node{
+		Kind:  yaml.Kind(v.FieldByName("Kind").Uint()),
+		Style: yaml.Style(v.FieldByName("Style").Uint()),
+		Tag:   v.FieldByName("Tag").String(),
+		Value: v.FieldByName("Value").String(),
+	}
+
+	return node.Kind == 0 || (node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null")
+}
+
+// isNumber reports whether the value is of an integer, unsigned integer or
+// floating point kind.
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive // Only numeric kinds are relevant
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
 	}
 
 	return false
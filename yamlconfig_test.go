@@ -6,6 +6,7 @@ import (
 
 	"github.com/sculley/yamlconfig"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 type TestConfigStruct struct {
@@ -38,6 +39,35 @@ type TestConfigOmitEmpty struct {
 	Slice  []string          `yaml:"slice" yamlconfig:"omitempty"`
 }
 
+type TestConfigOptionalSection struct {
+	Name   string `yaml:"name"`
+	Server struct {
+		Host string `yaml:"host" yamlconfig:"omitempty"`
+		Port int    `yaml:"port" yamlconfig:"omitempty"`
+	} `yaml:"server"`
+}
+
+type TestConfigRawSection struct {
+	Kind     string               `yaml:"kind"`
+	Spec     yaml.Node            `yaml:"spec"`
+	Extra    yaml.Node            `yaml:"extra" yamlconfig:"omitempty"`
+	Handlers map[string]yaml.Node `yaml:"handlers" yamlconfig:"omitempty"`
+}
+
+type TestConfigInlineBase struct {
+	Name string `yaml:"name"`
+	Port int    `yaml:"port"`
+}
+
+type TestConfigInline struct {
+	TestConfigInlineBase `yaml:",inline"`
+	Server               struct {
+		TestConfigInlineBase `yaml:",inline"`
+		Host                 string `yaml:"host"`
+	} `yaml:"server"`
+	Extra map[string]string `yaml:",inline"`
+}
+
 func TestConfig(t *testing.T) {
 	t.Run("Load Config", func(t *testing.T) {
 		cfg := TestConfigStruct{}
@@ -109,6 +139,18 @@ func TestConfig(t *testing.T) {
 		require.Error(t, loadConfigErr)
 	})
 
+	t.Run("Load Config Non-Pointer Or Nil", func(t *testing.T) {
+		path := writeTempConfig(t, "string: test\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, TestConfigEmpty{}),
+			"config must be a non-nil pointer to a struct or map, got yamlconfig_test.TestConfigEmpty")
+		require.EqualError(t, yamlconfig.LoadConfig("nonexistent.yml", (*TestConfigEmpty)(nil)),
+			"config must be a non-nil pointer to a struct or map, got *yamlconfig_test.TestConfigEmpty")
+		require.EqualError(t, yamlconfig.LoadConfig(path, nil), "config must be a non-nil pointer to a struct or map, got <nil>")
+		require.EqualError(t, yamlconfig.ValidateBytes([]byte("string: test\n"), TestConfigEmpty{}),
+			"config must be a non-nil pointer to a struct or map, got yamlconfig_test.TestConfigEmpty")
+	})
+
 	t.Run("Load Config Missing Config", func(t *testing.T) {
 		cfg := TestConfigEmpty{}
 
@@ -147,6 +189,44 @@ func TestConfig(t *testing.T) {
 		require.Error(t, loadConfigErr)
 	})
 
+	t.Run("Load Config Missing Required Struct", func(t *testing.T) {
+		cfg := TestConfigEmptyStruct{}
+		path := writeTempConfig(t, "string: test\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: struct: missing required config item")
+
+		cfg = TestConfigEmptyStruct{}
+		loadConfigErr = yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithValueBasedRequired())
+		require.EqualError(t, loadConfigErr, "failed to load the config: struct: missing required config item")
+	})
+
+	t.Run("Load Config Missing Struct With Optional Fields", func(t *testing.T) {
+		cfg := TestConfigOptionalSection{}
+		path := writeTempConfig(t, "name: app\n")
+
+		require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: server: missing required config item")
+
+		cfg = TestConfigOptionalSection{}
+		require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, "name: app\nserver:\n  port: 80\n"), &cfg))
+		require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, "name: app\nserver: {}\n"), &cfg))
+
+		cfg = TestConfigOptionalSection{}
+		loadConfigErr := yamlconfig.LoadConfig(writeTempConfig(t, "name: app\nserver: {}\n"), &cfg, yamlconfig.WithValueBasedRequired())
+		require.EqualError(t, loadConfigErr, "failed to load the config: server: missing required config item")
+	})
+
+	t.Run("Load Config Missing Optional Struct", func(t *testing.T) {
+		cfg := struct {
+			Name string                `yaml:"name"`
+			TLS  TestConfigEmptyStruct `yaml:"tls" yamlconfig:"omitempty"`
+		}{}
+
+		require.NoError(t, yamlconfig.LoadConfig(writeTempConfig(t, "name: app\n"), &cfg))
+		require.EqualError(t, yamlconfig.LoadConfig(writeTempConfig(t, "name: app\ntls:\n  string: a\n"), &cfg),
+			"failed to load the config: tls.struct: missing required config item")
+	})
+
 	t.Run("Load Config With OmitEmpty - All Fields Present", func(t *testing.T) {
 		cfg := TestConfigOmitEmpty{}
 		tempConfigFile, tempConfigFileErr := os.CreateTemp("", "omit_empty_config_all.yml")
@@ -181,6 +261,22 @@ func TestConfig(t *testing.T) {
 		require.Nil(t, cfg.Slice)
 	})
 
+	t.Run("Load Config From Stdin", func(t *testing.T) {
+		cfg := TestConfigEmpty{}
+
+		stdin, openErr := os.Open(writeTempConfig(t, "string: test\n"))
+		require.NoError(t, openErr)
+		defer stdin.Close()
+
+		originalStdin := os.Stdin
+		os.Stdin = stdin
+		defer func() { os.Stdin = originalStdin }()
+
+		loadConfigErr := yamlconfig.LoadConfig("-", &cfg)
+		require.NoError(t, loadConfigErr)
+		require.Equal(t, "test", cfg.String)
+	})
+
 	t.Run("Load Config With OmitEmpty - Missing Required Field", func(t *testing.T) {
 		cfg := TestConfigOmitEmpty{}
 		tempConfigFile, tempConfigFileErr := os.CreateTemp("", "omit_empty_config_missing_required.yml")
@@ -193,4 +289,91 @@ func TestConfig(t *testing.T) {
 		loadConfigErr := yamlconfig.LoadConfig(tempConfigFile.Name(), &cfg)
 		require.Error(t, loadConfigErr)
 	})
+
+	t.Run("Load Config Map Root", func(t *testing.T) {
+		cfg := map[string]TestServiceConfig{}
+		path := writeTempConfig(t, "api:\n  host: api.local\nweb:\n  host: web.local\n  port: 8080\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, TestServiceConfig{Host: "api.local", Port: 80, Timeout: "5s"}, cfg["api"])
+		require.Equal(t, 8080, cfg["web"].Port)
+	})
+
+	t.Run("Load Config Map Root Invalid", func(t *testing.T) {
+		cfg := map[string]*TestServiceConfig{}
+		path := writeTempConfig(t, "api:\n  port: 80\nweb:\n  host: web.local\n  timeout: 1m\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: api.host: missing required config item; "+
+			"web.timeout: value 1m must be one of: 5s 10s")
+	})
+
+	t.Run("Load Config Inline Structs", func(t *testing.T) {
+		cfg := TestConfigInline{}
+		path := writeTempConfig(t, "name: app\nport: 0\nserver:\n  name: api\n  port: 80\n  host: a\nregion: eu\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "app", cfg.Name)
+		require.Equal(t, 80, cfg.Server.Port)
+		require.Equal(t, map[string]string{"region": "eu"}, cfg.Extra)
+	})
+
+	t.Run("Load Config Inline Struct Missing Required Field", func(t *testing.T) {
+		cfg := TestConfigInline{}
+		path := writeTempConfig(t, "port: 1\nserver:\n  name: api\n  host: a\n")
+
+		loadConfigErr := yamlconfig.LoadConfig(path, &cfg, yamlconfig.WithErrorMode(yamlconfig.Collect))
+		require.EqualError(t, loadConfigErr, "failed to load the config: name: missing required config item; server.port: missing required config item")
+	})
+
+	t.Run("Load Config Raw Node Sections", func(t *testing.T) {
+		cfg := TestConfigRawSection{}
+		path := writeTempConfig(t, "kind: bucket\nspec:\n  name: logs\n  versioned: true\nextra: [a]\nhandlers:\n  a: {path: /}\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+
+		var spec struct {
+			Name      string `yaml:"name"`
+			Versioned bool   `yaml:"versioned"`
+		}
+		require.NoError(t, cfg.Spec.Decode(&spec))
+		require.Equal(t, "logs", spec.Name)
+		require.True(t, spec.Versioned)
+		require.Equal(t, yaml.SequenceNode, cfg.Extra.Kind)
+		require.Equal(t, yaml.MappingNode, cfg.Handlers["a"].Kind)
+	})
+
+	t.Run("Load Config Raw Node Section Missing", func(t *testing.T) {
+		for _, content := range []string{"kind: bucket\n", "kind: bucket\nspec: null\n", "kind: bucket\nspec:\n"} {
+			cfg := TestConfigRawSection{}
+			path := writeTempConfig(t, content)
+
+			require.EqualError(t, yamlconfig.LoadConfig(path, &cfg), "failed to load the config: spec: missing required config item")
+		}
+	})
+
+	t.Run("Load Config Raw Node Scalar", func(t *testing.T) {
+		cfg := TestConfigRawSection{}
+		path := writeTempConfig(t, "kind: bucket\nspec: 0\nextra: ~\n")
+
+		require.NoError(t, yamlconfig.LoadConfig(path, &cfg))
+		require.Equal(t, "0", cfg.Spec.Value)
+		require.Equal(t, "!!null", cfg.Extra.ShortTag())
+	})
+}
+
+// writeTempConfig writes content to a temporary config file that is removed
+// when the test finishes and returns its path.
+func writeTempConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	tempConfigFile, tempConfigFileErr := os.CreateTemp("", "config.test.yml")
+	require.NoError(t, tempConfigFileErr)
+	t.Cleanup(func() { os.Remove(tempConfigFile.Name()) })
+
+	_, writeStringErr := tempConfigFile.WriteString(content)
+	require.NoError(t, writeStringErr)
+	require.NoError(t, tempConfigFile.Close())
+
+	return tempConfigFile.Name()
 }